@@ -2,9 +2,14 @@ package main
 
 import (
 	"errors"
+	"io"
+	"os"
 	"strings"
 )
 
+// stdinText is the text argument that reads the text from standard input.
+const stdinText = "-"
+
 // ParseArgs parses command-line arguments and extracts text and banner name.
 //
 // The function validates argument count, extracts the text argument, interprets
@@ -39,3 +44,18 @@ func ParseArgs(args []string) (text string, banner string, err error) {
 
 	return text, banner, nil
 }
+
+// textReader returns the source of the text to render: standard input for the
+// text argument "-", or the text itself.
+//
+// Parameters:
+//   - text: The text argument, with escape sequences interpreted.
+//
+// Returns:
+//   - A reader for the text.
+func textReader(text string) io.Reader {
+	if text == stdinText {
+		return os.Stdin
+	}
+	return strings.NewReader(text)
+}
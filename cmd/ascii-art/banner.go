@@ -2,8 +2,17 @@ package main
 
 import (
 	"embed"
+	"errors"
 	"fmt"
 	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ascii-art-fs/internal/banner"
+	"ascii-art-fs/internal/parser"
+	"ascii-art-fs/internal/renderer"
+	"ascii-art-fs/internal/transform"
 )
 
 // bannerFS embeds the testdata directory into the compiled binary.
@@ -11,56 +20,418 @@ import (
 // from any directory without requiring testdata files to exist on disk.
 // The embedded files are read-only and frozen at compile time.
 //
+// The built-in banners are also precompiled into banners_gen.go, so rendering
+// with them needs no parsing; the embedded files remain the source of truth and
+// are used for listing, linting and fallback chains.
+//
+//go:generate go run genbanners.go -o banners_gen.go testdata/standard.txt testdata/shadow.txt testdata/thinkertoy.txt
 //go:embed testdata/*.txt
 var bannerFS embed.FS
 
-var bannerPaths = map[string]string{
-	"standard":   "testdata/standard.txt",
-	"shadow":     "testdata/shadow.txt",
-	"thinkertoy": "testdata/thinkertoy.txt",
+// bannerPathEnv lists extra banner directories, separated like $PATH.
+const bannerPathEnv = "ASCII_ART_BANNER_PATH"
+
+// builtinBanners lists the banners embedded in the binary, in the order they are
+// presented to users.
+var builtinBanners = []banner.Spec{
+	{Name: "standard", DisplayName: "Standard", Path: "testdata/standard.txt"},
+	{Name: "shadow", DisplayName: "Shadow", Path: "testdata/shadow.txt"},
+	{Name: "thinkertoy", DisplayName: "Thinkertoy", Path: "testdata/thinkertoy.txt"},
+}
+
+// bannerChainSep separates the banners of a fallback chain such as "mylogo,standard".
+const bannerChainSep = ","
+
+// bannerExtensions lists the banner file extensions, in order of preference when
+// a user banner directory holds several files with the same name.
+var bannerExtensions = []string{".txt", ".flf", ".json"}
+
+// banners is the registry used to resolve banner arguments. It holds the
+// built-in banners until setBannerDirs layers user banner directories over them.
+var banners = newBannerRegistry(nil)
+
+// bannerSearchPath returns the user banner directories in priority order:
+// directories given with --banner-dir, then the entries of ASCII_ART_BANNER_PATH,
+// then $XDG_DATA_HOME/ascii-art/banners (defaulting to ~/.local/share).
+//
+// Parameters:
+//   - flagDirs: Directories passed with --banner-dir, in command-line order.
+//
+// Returns:
+//   - The directories to search before the embedded banners.
+func bannerSearchPath(flagDirs []string) []string {
+	dirs := append([]string{}, flagDirs...)
+
+	for _, dir := range filepath.SplitList(os.Getenv(bannerPathEnv)) {
+		if dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			dataHome = filepath.Join(home, ".local", "share")
+		}
+	}
+	if dataHome != "" {
+		dirs = append(dirs, filepath.Join(dataHome, "ascii-art", "banners"))
+	}
+
+	return dirs
+}
+
+// setBannerDirs rebuilds the banner registry with the given user banner
+// directories layered over the built-in banners.
+//
+// Parameters:
+//   - dirs: The user banner directories, highest priority first.
+func setBannerDirs(dirs []string) {
+	banners = newBannerRegistry(dirs)
+}
+
+// newBannerRegistry builds a registry holding the built-in banners, shadowed by
+// the banner files found in the user banner directories.
+//
+// Directories are registered from lowest to highest priority so that files in
+// earlier directories replace those in later ones. Unreadable directories, and
+// files without a usable banner name such as ".txt" or hidden files, are
+// skipped, so a stray file never stops the program. User banner files are
+// parsed in lenient mode, so that files saved with a byte order mark, CR line
+// endings or trailing blank lines still load.
+//
+// Parameters:
+//   - dirs: The user banner directories, highest priority first.
+//
+// Returns:
+//   - The populated banner registry.
+func newBannerRegistry(dirs []string) *banner.Registry {
+	reg := banner.NewRegistry()
+	for _, spec := range builtinBanners {
+		spec.FS = bannerFS
+		spec.Source = banner.SourceEmbedded
+		spec.Banner = precompiledBanners[spec.Path]
+		mustRegister(reg, spec)
+	}
+
+	for i := len(dirs) - 1; i >= 0; i-- {
+		entries, err := os.ReadDir(dirs[i])
+		if err != nil {
+			continue
+		}
+		fsys := os.DirFS(dirs[i])
+		for j := len(bannerExtensions) - 1; j >= 0; j-- {
+			ext := bannerExtensions[j]
+			for _, e := range entries {
+				name := strings.TrimSuffix(e.Name(), ext)
+				if e.IsDir() || filepath.Ext(e.Name()) != ext || name == "" || strings.HasPrefix(name, ".") {
+					continue
+				}
+				if err := reg.Register(banner.Spec{
+					Name:   name,
+					FS:     fsys,
+					Path:   e.Name(),
+					Source: banner.SourceUser,
+					Mode:   parser.Lenient,
+				}); err != nil {
+					continue
+				}
+			}
+		}
+	}
+	return reg
+}
+
+// mustRegister registers a banner whose spec is known to be valid.
+func mustRegister(reg *banner.Registry, spec banner.Spec) {
+	if err := reg.Register(spec); err != nil {
+		panic(err)
+	}
 }
 
 // GetBannerPath converts a banner name to its corresponding file path.
 //
-// The function validates the banner name against a predefined map of valid banners
-// (standard, shadow, thinkertoy) and returns the appropriate file path in the testdata
-// directory.
+// The name is resolved through the banner registry, so banners from user banner
+// directories shadow the built-in ones (standard, shadow, thinkertoy). The path
+// is relative to the filesystem the banner is registered with.
 //
 // Parameters:
-//   - banner: The banner name to resolve.
+//   - name: The banner name to resolve.
 //
 // Returns:
 //   - The file path to the banner file.
-//   - An error if the banner name is invalid.
-func GetBannerPath(banner string) (string, error) {
-	path, exists := bannerPaths[banner]
-	if !exists {
-		return "", fmt.Errorf("invalid banner name: %q\nValid options: standard, shadow, thinkertoy", banner)
+//   - An error listing the valid banner names if the name is invalid.
+func GetBannerPath(name string) (string, error) {
+	info, err := banners.Resolve(name)
+	if err != nil {
+		return "", err
 	}
+	return info.Path, nil
+}
 
-	return path, nil
+// loadBanner loads a banner by registered name or by file path.
+//
+// Arguments that look like file paths (they contain a path separator or end in
+// ".txt", ".flf" or ".json") are parsed directly from disk in lenient mode, like the
+// files in user banner directories; every other argument is loaded through the
+// banner registry.
+//
+// Parameters:
+//   - name: The banner name or banner file path.
+//
+// Returns:
+//   - The parsed banner.
+//   - A *banner.UnknownBannerError if the name is not registered, the read
+//     error, or a *parser.ParseError.
+func loadBanner(name string) (parser.Banner, error) {
+	if isBannerFilePath(name) {
+		return parser.LoadWithMode(os.DirFS(filepath.Dir(name)), filepath.Base(name), parser.Lenient)
+	}
+	return banners.Load(name)
 }
 
-// isValidBanner checks whether a string is a recognized banner name.
+// loadBannerChain loads a banner argument that may name a fallback chain.
+//
+// A single banner is loaded with loadBanner and must be complete. For a chain
+// like "mylogo,standard" every banner is loaded as a partial banner and the
+// chain is combined with parser.Fallback, so characters missing from a banner
+// are taken from the next one that defines them.
 //
 // Parameters:
-//   - name: The banner name to validate.
+//   - spec: A banner name or path, or several separated by commas.
 //
 // Returns:
-//   - true if the banner name is valid (standard, shadow, or thinkertoy), false otherwise.
-func isValidBanner(name string) bool {
-	_, exists := bannerPaths[name]
-	return exists
+//   - The parsed, combined banner.
+//   - Warnings about partial banners in the chain.
+//   - A *banner.UnknownBannerError if a name is not registered, or the parse error.
+func loadBannerChain(spec string) (parser.Banner, []string, error) {
+	names := strings.Split(spec, bannerChainSep)
+	if len(names) == 1 {
+		charMap, err := loadBanner(spec)
+		return charMap, nil, err
+	}
+
+	chain := make([]parser.Banner, 0, len(names))
+	var warnings []string
+	for _, name := range names {
+		if name == "" {
+			return nil, nil, fmt.Errorf("empty banner name in fallback chain %q", spec)
+		}
+		charMap, bannerWarnings, err := loadPartialBanner(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		chain = append(chain, charMap)
+		warnings = append(warnings, bannerWarnings...)
+	}
+	return parser.Fallback(chain...), warnings, nil
 }
 
-// GetBannerFS returns the embedded filesystem containing banner files.
+// loadPartialBanner loads a banner that may define only some glyphs, by
+// registered name or by file path.
 //
-// The filesystem is embedded at compile time and contains all banner files
-// from the testdata directory. This allows the binary to be fully relocatable
-// and run from any directory without requiring external data files.
+// Parameters:
+//   - name: The banner name or banner file path.
 //
 // Returns:
-//   - An fs.FS interface to the embedded testdata directory.
-func GetBannerFS() fs.FS {
-	return bannerFS
+//   - The parsed banner.
+//   - Warnings such as missing glyphs.
+//   - A *banner.UnknownBannerError if the name is not registered, or the parse error.
+func loadPartialBanner(name string) (parser.Banner, []string, error) {
+	if isBannerFilePath(name) {
+		return parser.LoadPartialWithMode(os.DirFS(filepath.Dir(name)), filepath.Base(name), parser.Lenient)
+	}
+	info, err := banners.Resolve(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if info.Source == banner.SourceEmbedded {
+		// The embedded banners are complete and precompiled.
+		charMap, err := banners.Load(name)
+		return charMap, nil, err
+	}
+	return parser.LoadPartialWithMode(info.FS, info.Path, info.Mode)
+}
+
+// loadBannerMetadata reads the metadata of a banner, by registered name or by
+// file path.
+//
+// Parameters:
+//   - name: The banner name or banner file path.
+//
+// Returns:
+//   - The metadata, which is zero if the banner declares none.
+//   - A *banner.UnknownBannerError if the name is not registered, the read
+//     error, or a *parser.ParseError.
+func loadBannerMetadata(name string) (parser.Metadata, error) {
+	if isBannerFilePath(name) {
+		return parser.LoadMetadata(os.DirFS(filepath.Dir(name)), filepath.Base(name), parser.Lenient)
+	}
+	info, err := banners.Resolve(name)
+	if err != nil {
+		return parser.Metadata{}, err
+	}
+	return parser.LoadMetadata(info.FS, info.Path, info.Mode)
+}
+
+// loadBannerOrExit loads a banner or fallback chain with loadBannerChain,
+// printing any warnings to stderr, and exits the program with the message and
+// exit code chosen by describeBannerError when loading fails.
+//
+// Parameters:
+//   - name: The banner name, banner file path, or fallback chain.
+//   - t: The --transform to apply to the loaded banner, or nil.
+//
+// Returns:
+//   - The parsed, transformed banner.
+func loadBannerOrExit(name string, t transform.Transform) parser.Banner {
+	charMap, warnings, err := loadBannerChain(name)
+	for _, w := range warnings {
+		fmt.Fprintln(os.Stderr, "Warning:", w)
+	}
+	if err == nil {
+		if t != nil {
+			charMap = t(charMap)
+		}
+		return charMap
+	}
+
+	msg, code := describeBannerError(err)
+	fmt.Fprintln(os.Stderr, msg)
+	os.Exit(code)
+	return nil
+}
+
+// loadLayoutBannerOrExit loads a banner with loadBannerOrExit and returns it
+// with the renderer options selected on the command line.
+//
+// Kerning and smushing need to tell the hardblanks of a FIGlet font apart
+// from its spaces, so when one of them is selected and name is a single
+// FIGlet font, the font's glyphs are reloaded with their hardblanks kept and
+// the hardblank is set in the options.
+//
+// Parameters:
+//   - name: The banner name, banner file path, or fallback chain.
+//   - opts: The extended options, for the transform and layout.
+//
+// Returns:
+//   - The parsed, transformed banner.
+//   - The renderer options.
+func loadLayoutBannerOrExit(name string, opts cliOptions) (parser.Banner, renderer.Options) {
+	charMap := loadBannerOrExit(name, opts.transform)
+	layout := opts.renderOptions()
+	if layout.Layout == renderer.LayoutFull {
+		return charMap, layout
+	}
+
+	font, err := loadFIGletFont(name)
+	if err != nil || font == nil {
+		return charMap, layout
+	}
+	glyphs := font.Glyphs
+	if opts.transform != nil {
+		glyphs = opts.transform(glyphs)
+	}
+	layout.Hardblank = font.Header.Hardblank
+	return glyphs, layout
+}
+
+// loadFIGletFont loads a banner argument as a FIGlet font with its hardblanks
+// kept, when it names a single FIGlet font by file path or registered name.
+//
+// Parameters:
+//   - name: The banner name, banner file path, or fallback chain.
+//
+// Returns:
+//   - The font, or nil if name is not a single FIGlet font.
+//   - An error if the font cannot be read or parsed.
+func loadFIGletFont(name string) (*parser.FIGletFont, error) {
+	if strings.Contains(name, bannerChainSep) {
+		return nil, nil
+	}
+	if isBannerFilePath(name) {
+		if !parser.IsFIGletPath(name) {
+			return nil, nil
+		}
+		return parser.LoadFIGletFont(os.DirFS(filepath.Dir(name)), filepath.Base(name))
+	}
+	info, err := banners.Resolve(name)
+	if err != nil || !parser.IsFIGletPath(info.Path) {
+		return nil, err
+	}
+	return parser.LoadFIGletFont(info.FS, info.Path)
+}
+
+// describeBannerError chooses the message and exit code for a banner loading
+// error from its type: unknown banner names are usage errors, missing files
+// and malformed files have exit codes of their own, and any other failure,
+// such as a permission problem, is a generic banner file error.
+//
+// Parameters:
+//   - err: The error returned by loadBannerChain.
+//
+// Returns:
+//   - The message to print to stderr.
+//   - The exit code.
+func describeBannerError(err error) (string, int) {
+	var unknown *banner.UnknownBannerError
+	var parseErr *parser.ParseError
+	switch {
+	case errors.As(err, &unknown):
+		return "Error: " + err.Error(), exitCodeUsageError
+	case errors.Is(err, fs.ErrNotExist):
+		return "Error: banner file not found: " + err.Error(), exitCodeBannerNotFound
+	case errors.As(err, &parseErr):
+		msg := "Error: invalid banner file: " + err.Error()
+		if !parser.IsFIGletPath(parseErr.Path) {
+			msg += "\nRun \"ascii-art lint <file>\" to list every problem in the banner."
+		}
+		return msg, exitCodeBannerInvalid
+	default:
+		return "Error loading banner file: " + err.Error(), exitCodeBannerError
+	}
+}
+
+// isBannerFilePath reports whether a banner argument should be treated as a
+// path to a banner file rather than a banner name.
+func isBannerFilePath(name string) bool {
+	if strings.ContainsRune(name, '/') || strings.ContainsRune(name, filepath.Separator) {
+		return true
+	}
+	for _, ext := range bannerExtensions {
+		if strings.EqualFold(filepath.Ext(name), ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidBanner checks whether a string is a recognized banner name or a
+// fallback chain of recognized banner names.
+//
+// Parameters:
+//   - name: The banner name to validate.
+//
+// Returns:
+//   - true if the name is registered (a built-in banner or one from a user
+//     banner directory), a FIGlet font file name, an existing banner file
+//     path, or a comma-separated chain of these; false otherwise.
+func isValidBanner(name string) bool {
+	if names := strings.Split(name, bannerChainSep); len(names) > 1 {
+		for _, n := range names {
+			if n == "" || !isValidBanner(n) {
+				return false
+			}
+		}
+		return true
+	}
+	if parser.IsFIGletPath(name) {
+		return true
+	}
+	if isBannerFilePath(name) {
+		info, err := os.Stat(name)
+		return err == nil && info.Mode().IsRegular()
+	}
+	_, registered := banners.Lookup(name)
+	return registered
 }
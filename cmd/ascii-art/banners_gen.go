@@ -0,0 +1,300 @@
+// Code generated by genbanners.go; DO NOT EDIT.
+
+package main
+
+import "ascii-art-fs/internal/parser"
+
+// precompiledBanners holds the parsed embedded banners, keyed by their path in bannerFS.
+var precompiledBanners = map[string]parser.Banner{
+	"testdata/standard.txt": {
+		' ':  {"      ", "      ", "      ", "      ", "      ", "      ", "      ", "      "},
+		'!':  {" _  ", "| | ", "| | ", "| | ", "|_| ", "(_) ", "    ", "    "},
+		'"':  {" _ _  ", "( | ) ", " V V  ", "      ", "      ", "      ", "      ", "      "},
+		'#':  {"   _  _    ", " _| || |_  ", "|_  __  _| ", " _| || |_  ", "|_  __  _| ", "  |_||_|   ", "           ", "           "},
+		'$':  {"  _   ", " | |  ", "/ __) ", "\\__ \\ ", "(   / ", " |_|  ", "      ", "      "},
+		'%':  {" _   __ ", "(_) / / ", "   / /  ", "  / /   ", " / / _  ", "/_/ (_) ", "        ", "        "},
+		'&':  {"         ", "  ___    ", " ( _ )   ", " / _ \\/\\ ", "| (_>  < ", " \\___/\\/ ", "         ", "         "},
+		'\'': {" _  ", "( ) ", "|/  ", "    ", "    ", "    ", "    ", "    "},
+		'(':  {"  __ ", " / / ", "| |  ", "| |  ", "| |  ", "| |  ", " \\_\\ ", "     "},
+		')':  {"__   ", "\\ \\  ", " | | ", " | | ", " | | ", " | | ", "/_/  ", "     "},
+		'*':  {"    _     ", " /\\| |/\\  ", " \\ ` ' /  ", "|_     _| ", " / , . \\  ", " \\/|_|\\/  ", "          ", "          "},
+		'+':  {"        ", "   _    ", " _| |_  ", "|_   _| ", "  |_|   ", "        ", "        ", "        "},
+		',':  {"    ", "    ", "    ", "    ", " _  ", "( ) ", "|/  ", "    "},
+		'-':  {"         ", "         ", " ______  ", "|______| ", "         ", "         ", "         ", "         "},
+		'.':  {"    ", "    ", "    ", "    ", " _  ", "(_) ", "    ", "    "},
+		'/':  {"     __ ", "    / / ", "   / /  ", "  / /   ", " / /    ", "/_/     ", "        ", "        "},
+		'0':  {"        ", "  ___   ", " / _ \\  ", "| | | | ", "| |_| | ", " \\___/  ", "        ", "        "},
+		'1':  {"    ", " _  ", "/ | ", "| | ", "| | ", "|_| ", "    ", "    "},
+		'2':  {"        ", " ____   ", "|___ \\  ", "  __) | ", " / __/  ", "|_____| ", "        ", "        "},
+		'3':  {"        ", " _____  ", "|___ /  ", "  |_ \\  ", " ___) | ", "|____/  ", "        ", "        "},
+		'4':  {"         ", " _  _    ", "| || |   ", "| || |_  ", "|__   _| ", "   |_|   ", "         ", "         "},
+		'5':  {"        ", " ____   ", "| ___|  ", "|___ \\  ", "  __) | ", "|____/  ", "        ", "        "},
+		'6':  {"        ", "  __    ", " / /    ", "| '_ \\  ", "| (_) | ", " \\___/  ", "        ", "        "},
+		'7':  {"        ", " _____  ", "|___  | ", "   / /  ", "  / /   ", " /_/    ", "        ", "        "},
+		'8':  {"        ", "  ___   ", " ( _ )  ", " / _ \\  ", "| (_) | ", " \\___/  ", "        ", "        "},
+		'9':  {"        ", "  ___   ", " / _ \\  ", "| (_) | ", " \\__, | ", "   / /  ", "  /_/   ", "        "},
+		':':  {"    ", " _  ", "(_) ", "    ", " _  ", "(_) ", "    ", "    "},
+		';':  {"    ", " _  ", "(_) ", "    ", " _  ", "( ) ", "|/  ", "    "},
+		'<':  {"   __ ", "  / / ", " / /  ", "< <   ", " \\ \\  ", "  \\_\\ ", "      ", "      "},
+		'=':  {"         ", " ______  ", "|______| ", " ______  ", "|______| ", "         ", "         ", "         "},
+		'>':  {"__    ", "\\ \\   ", " \\ \\  ", "  > > ", " / /  ", "/_/   ", "      ", "      "},
+		'?':  {" ___   ", "|__ \\  ", "   ) | ", "  / /  ", " |_|   ", " (_)   ", "       ", "       "},
+		'@':  {"          ", "   ____   ", "  / __ \\  ", " / / _` | ", "| | (_| | ", " \\ \\__,_| ", "  \\____/  ", "          "},
+		'A':  {"           ", "    /\\     ", "   /  \\    ", "  / /\\ \\   ", " / ____ \\  ", "/_/    \\_\\ ", "           ", "           "},
+		'B':  {" ____   ", "|  _ \\  ", "| |_) | ", "|  _ <  ", "| |_) | ", "|____/  ", "        ", "        "},
+		'C':  {"  _____  ", " / ____| ", "| |      ", "| |      ", "| |____  ", " \\_____| ", "         ", "         "},
+		'D':  {" _____   ", "|  __ \\  ", "| |  | | ", "| |  | | ", "| |__| | ", "|_____/  ", "         ", "         "},
+		'E':  {" ______  ", "|  ____| ", "| |__    ", "|  __|   ", "| |____  ", "|______| ", "         ", "         "},
+		'F':  {" ______  ", "|  ____| ", "| |__    ", "|  __|   ", "| |      ", "|_|      ", "         ", "         "},
+		'G':  {"  _____  ", " / ____| ", "| |  __  ", "| | |_ | ", "| |__| | ", " \\_____| ", "         ", "         "},
+		'H':  {" _    _  ", "| |  | | ", "| |__| | ", "|  __  | ", "| |  | | ", "|_|  |_| ", "         ", "         "},
+		'I':  {" _____  ", "|_   _| ", "  | |   ", "  | |   ", " _| |_  ", "|_____| ", "        ", "        "},
+		'J':  {"      _  ", "     | | ", "     | | ", " _   | | ", "| |__| | ", " \\____/  ", "         ", "         "},
+		'K':  {" _  __ ", "| |/ / ", "| ' /  ", "|  <   ", "| . \\  ", "|_|\\_\\ ", "       ", "       "},
+		'L':  {" _       ", "| |      ", "| |      ", "| |      ", "| |____  ", "|______| ", "         ", "         "},
+		'M':  {" __  __  ", "|  \\/  | ", "| \\  / | ", "| |\\/| | ", "| |  | | ", "|_|  |_| ", "         ", "         "},
+		'N':  {" _   _  ", "| \\ | | ", "|  \\| | ", "| . ` | ", "| |\\  | ", "|_| \\_| ", "        ", "        "},
+		'O':  {"  ____   ", " / __ \\  ", "| |  | | ", "| |  | | ", "| |__| | ", " \\____/  ", "         ", "         "},
+		'P':  {" _____   ", "|  __ \\  ", "| |__) | ", "|  ___/  ", "| |      ", "|_|      ", "         ", "         "},
+		'Q':  {"  ____   ", " / __ \\  ", "| |  | | ", "| |  | | ", "| |__| | ", " \\___\\_\\ ", "         ", "         "},
+		'R':  {" _____   ", "|  __ \\  ", "| |__) | ", "|  _  /  ", "| | \\ \\  ", "|_|  \\_\\ ", "         ", "         "},
+		'S':  {"  _____  ", " / ____| ", "| (___   ", " \\___ \\  ", " ____) | ", "|_____/  ", "         ", "         "},
+		'T':  {" _______  ", "|__   __| ", "   | |    ", "   | |    ", "   | |    ", "   |_|    ", "          ", "          "},
+		'U':  {" _    _  ", "| |  | | ", "| |  | | ", "| |  | | ", "| |__| | ", " \\____/  ", "         ", "         "},
+		'V':  {"__      __ ", "\\ \\    / / ", " \\ \\  / /  ", "  \\ \\/ /   ", "   \\  /    ", "    \\/     ", "           ", "           "},
+		'W':  {"__          __ ", "\\ \\        / / ", " \\ \\  /\\  / /  ", "  \\ \\/  \\/ /   ", "   \\  /\\  /    ", "    \\/  \\/     ", "               ", "               "},
+		'X':  {"__   __ ", "\\ \\ / / ", " \\ V /  ", "  > <   ", " / . \\  ", "/_/ \\_\\ ", "        ", "        "},
+		'Y':  {"__     __ ", "\\ \\   / / ", " \\ \\_/ /  ", "  \\   /   ", "   | |    ", "   |_|    ", "          ", "          "},
+		'Z':  {" ______ ", "|___  / ", "   / /  ", "  / /   ", " / /__  ", "/_____| ", "        ", "        "},
+		'[':  {" ___  ", "|  _| ", "| |   ", "| |   ", "| |   ", "| |_  ", "|___| ", "      "},
+		'\\': {"__      ", "\\ \\     ", " \\ \\    ", "  \\ \\   ", "   \\ \\  ", "    \\_\\ ", "        ", "        "},
+		']':  {" ___  ", "|_  | ", "  | | ", "  | | ", "  | | ", " _| | ", "|___| ", "      "},
+		'^':  {" /\\  ", "|/\\| ", "     ", "     ", "     ", "     ", "     ", "     "},
+		'_':  {"         ", "         ", "         ", "         ", "         ", "         ", " ______  ", "|______| "},
+		'`':  {" _  ", "( ) ", " \\| ", "    ", "    ", "    ", "    ", "    "},
+		'a':  {"        ", "        ", "  __ _  ", " / _` | ", "| (_| | ", " \\__,_| ", "        ", "        "},
+		'b':  {" _      ", "| |     ", "| |__   ", "| '_ \\  ", "| |_) | ", "|_.__/  ", "        ", "        "},
+		'c':  {"       ", "       ", "  ___  ", " / __| ", "| (__  ", " \\___| ", "       ", "       "},
+		'd':  {"     _  ", "    | | ", "  __| | ", " / _` | ", "| (_| | ", " \\__,_| ", "        ", "        "},
+		'e':  {"       ", "       ", "  ___  ", " / _ \\ ", "|  __/ ", " \\___| ", "       ", "       "},
+		'f':  {"  __  ", " / _| ", "| |_  ", "|  _| ", "| |   ", "|_|   ", "      ", "      "},
+		'g':  {"        ", "        ", "  __ _  ", " / _` | ", "| (_| | ", " \\__, | ", "  __/ | ", " |___/  "},
+		'h':  {" _      ", "| |     ", "| |__   ", "|  _ \\  ", "| | | | ", "|_| |_| ", "        ", "        "},
+		'i':  {" _  ", "(_) ", " _  ", "| | ", "| | ", "|_| ", "    ", "    "},
+		'j':  {"   _  ", "  (_) ", "   _  ", "  | | ", "  | | ", "  | | ", " _/ | ", "|__/  "},
+		'k':  {"       ", " _     ", "| | _  ", "| |/ / ", "|   <  ", "|_|\\_\\ ", "       ", "       "},
+		'l':  {" _  ", "| | ", "| | ", "| | ", "| | ", "|_| ", "    ", "    "},
+		'm':  {"            ", "            ", " _ __ ___   ", "| '_ ` _ \\  ", "| | | | | | ", "|_| |_| |_| ", "            ", "            "},
+		'n':  {"        ", "        ", " _ __   ", "| '_ \\  ", "| | | | ", "|_| |_| ", "        ", "        "},
+		'o':  {"        ", "        ", "  ___   ", " / _ \\  ", "| (_) | ", " \\___/  ", "        ", "        "},
+		'p':  {"        ", "        ", " _ __   ", "| '_ \\  ", "| |_) | ", "| .__/  ", "| |     ", "|_|     "},
+		'q':  {"        ", "        ", "  __ _  ", " / _` | ", "| (_| | ", " \\__, | ", "    | | ", "    |_| "},
+		'r':  {"       ", "       ", " _ __  ", "| '__| ", "| |    ", "|_|    ", "       ", "       "},
+		's':  {"      ", "      ", " ___  ", "/ __| ", "\\__ \\ ", "|___/ ", "      ", "      "},
+		't':  {" _    ", "| |   ", "| |_  ", "| __| ", "\\ |_  ", " \\__| ", "      ", "      "},
+		'u':  {"        ", "        ", " _   _  ", "| | | | ", "| |_| | ", " \\__,_| ", "        ", "        "},
+		'v':  {"        ", "        ", "__   __ ", "\\ \\ / / ", " \\ V /  ", "  \\_/   ", "        ", "        "},
+		'w':  {"           ", "           ", "__      __ ", "\\ \\ /\\ / / ", " \\ V  V /  ", "  \\_/\\_/   ", "           ", "           "},
+		'x':  {"       ", "       ", "__  __ ", "\\ \\/ / ", " >  <  ", "/_/\\_\\ ", "       ", "       "},
+		'y':  {"        ", "        ", " _   _  ", "| | | | ", "| |_| | ", " \\__, | ", " __/ /  ", "|___/   "},
+		'z':  {"      ", "      ", " ____ ", "|_  / ", " / /  ", "/___| ", "      ", "      "},
+		'{':  {"   __ ", "  / / ", " | |  ", "/ /   ", "\\ \\   ", " | |  ", "  \\_\\ ", "      "},
+		'|':  {" _  ", "| | ", "| | ", "| | ", "| | ", "| | ", "| | ", "|_| "},
+		'}':  {"__    ", "\\ \\   ", " | |  ", "  \\ \\ ", "  / / ", " | |  ", "/_/   ", "      "},
+		'~':  {" /\\/| ", "|/\\/  ", "      ", "      ", "      ", "      ", "      ", "      "},
+	},
+	"testdata/shadow.txt": {
+		' ':  {"      ", "      ", "      ", "      ", "      ", "      ", "      ", "      "},
+		'!':  {"   ", "_| ", "_| ", "_| ", "   ", "_| ", "   ", "   "},
+		'"':  {"_|  _| ", "_|  _| ", "       ", "       ", "       ", "       ", "       ", "       "},
+		'#':  {"           ", "  _|  _|   ", "_|_|_|_|_| ", "  _|  _|   ", "_|_|_|_|_| ", "  _|  _|   ", "           ", "           "},
+		'$':  {"       ", "  _|   ", "_|_|_| ", "_|_|   ", "  _|_| ", "_|_|_| ", "  _|   ", "       "},
+		'%':  {"           ", "_|_|    _| ", "_|_|  _|   ", "    _|     ", "  _|  _|_| ", "_|    _|_| ", "           ", "           "},
+		'&':  {"           ", "  _|       ", "_|  _|     ", "  _|_|  _| ", "_|    _|   ", "  _|_|  _| ", "           ", "           "},
+		'\'': {"  _| ", "_|   ", "     ", "     ", "     ", "     ", "     ", "     "},
+		'(':  {"  _| ", "_|   ", "_|   ", "_|   ", "_|   ", "_|   ", "  _| ", "     "},
+		')':  {"_|   ", "  _| ", "  _| ", "  _| ", "  _| ", "  _| ", "_|   ", "     "},
+		'*':  {"           ", "_|  _|  _| ", "  _|_|_|   ", "_|_|_|_|_| ", "  _|_|_|   ", "_|  _|  _| ", "           ", "           "},
+		'+':  {"           ", "    _|     ", "    _|     ", "_|_|_|_|_| ", "    _|     ", "    _|     ", "           ", "           "},
+		',':  {"     ", "     ", "     ", "     ", "     ", "  _| ", "_|   ", "     "},
+		'-':  {"           ", "           ", "           ", "_|_|_|_|_| ", "           ", "           ", "           ", "           "},
+		'.':  {"   ", "   ", "   ", "   ", "   ", "_| ", "   ", "   "},
+		'/':  {"           ", "        _| ", "      _|   ", "    _|     ", "  _|       ", "_|         ", "           ", "           "},
+		'0':  {"       ", "  _|   ", "_|  _| ", "_|  _| ", "_|  _| ", "  _|   ", "       ", "       "},
+		'1':  {"     ", "  _| ", "_|_| ", "  _| ", "  _| ", "  _| ", "     ", "     "},
+		'2':  {"         ", "  _|_|   ", "_|    _| ", "    _|   ", "  _|     ", "_|_|_|_| ", "         ", "         "},
+		'3':  {"         ", "_|_|_|   ", "      _| ", "  _|_|   ", "      _| ", "_|_|_|   ", "         ", "         "},
+		'4':  {"         ", "_|  _|   ", "_|  _|   ", "_|_|_|_| ", "    _|   ", "    _|   ", "         ", "         "},
+		'5':  {"         ", "_|_|_|_| ", "_|       ", "_|_|_|   ", "      _| ", "_|_|_|   ", "         ", "         "},
+		'6':  {"         ", "  _|_|_| ", "_|       ", "_|_|_|   ", "_|    _| ", "  _|_|   ", "         ", "         "},
+		'7':  {"           ", "_|_|_|_|_| ", "        _| ", "      _|   ", "    _|     ", "  _|       ", "           ", "           "},
+		'8':  {"         ", "  _|_|   ", "_|    _| ", "  _|_|   ", "_|    _| ", "  _|_|   ", "         ", "         "},
+		'9':  {"         ", "  _|_|   ", "_|    _| ", "  _|_|_| ", "      _| ", "_|_|_|   ", "         ", "         "},
+		':':  {"   ", "   ", "_| ", "   ", "   ", "_| ", "   ", "   "},
+		';':  {"     ", "     ", "  _| ", "     ", "     ", "  _| ", "_|   ", "     "},
+		'<':  {"       ", "    _| ", "  _|   ", "_|     ", "  _|   ", "    _| ", "       ", "       "},
+		'=':  {"           ", "           ", "_|_|_|_|_| ", "           ", "_|_|_|_|_| ", "           ", "           ", "           "},
+		'>':  {"       ", "_|     ", "  _|   ", "    _| ", "  _|   ", "_|     ", "       ", "       "},
+		'?':  {"       ", "_|_|   ", "    _| ", "_|_|   ", "       ", "_|     ", "       ", "       "},
+		'@':  {"                 ", "    _|_|_|_|_|   ", "  _|          _| ", "_|    _|_|_|  _| ", "_|  _|    _|  _| ", "_|    _|_|_|_|   ", "  _|             ", "    _|_|_|_|_|_| "},
+		'A':  {"         ", "  _|_|   ", "_|    _| ", "_|_|_|_| ", "_|    _| ", "_|    _| ", "         ", "         "},
+		'B':  {"         ", "_|_|_|   ", "_|    _| ", "_|_|_|   ", "_|    _| ", "_|_|_|   ", "         ", "         "},
+		'C':  {"         ", "  _|_|_| ", "_|       ", "_|       ", "_|       ", "  _|_|_| ", "         ", "         "},
+		'D':  {"         ", "_|_|_|   ", "_|    _| ", "_|    _| ", "_|    _| ", "_|_|_|   ", "         ", "         "},
+		'E':  {"         ", "_|_|_|_| ", "_|       ", "_|_|_|   ", "_|       ", "_|_|_|_| ", "         ", "         "},
+		'F':  {"         ", "_|_|_|_| ", "_|       ", "_|_|_|   ", "_|       ", "_|       ", "         ", "         "},
+		'G':  {"         ", "  _|_|_| ", "_|       ", "_|  _|_| ", "_|    _| ", "  _|_|_| ", "         ", "         "},
+		'H':  {"         ", "_|    _| ", "_|    _| ", "_|_|_|_| ", "_|    _| ", "_|    _| ", "         ", "         "},
+		'I':  {"       ", "_|_|_| ", "  _|   ", "  _|   ", "  _|   ", "_|_|_| ", "       ", "       "},
+		'J':  {"         ", "      _| ", "      _| ", "      _| ", "_|    _| ", "  _|_|   ", "         ", "         "},
+		'K':  {"         ", "_|    _| ", "_|  _|   ", "_|_|     ", "_|  _|   ", "_|    _| ", "         ", "         "},
+		'L':  {"         ", "_|       ", "_|       ", "_|       ", "_|       ", "_|_|_|_| ", "         ", "         "},
+		'M':  {"           ", "_|      _| ", "_|_|  _|_| ", "_|  _|  _| ", "_|      _| ", "_|      _| ", "           ", "           "},
+		'N':  {"           ", "_|      _| ", "_|_|    _| ", "_|  _|  _| ", "_|    _|_| ", "_|      _| ", "           ", "           "},
+		'O':  {"         ", "  _|_|   ", "_|    _| ", "_|    _| ", "_|    _| ", "  _|_|   ", "         ", "         "},
+		'P':  {"         ", "_|_|_|   ", "_|    _| ", "_|_|_|   ", "_|       ", "_|       ", "         ", "         "},
+		'Q':  {"           ", "  _|_|     ", "_|    _|   ", "_|  _|_|   ", "_|    _|   ", "  _|_|  _| ", "           ", "           "},
+		'R':  {"         ", "_|_|_|   ", "_|    _| ", "_|_|_|   ", "_|    _| ", "_|    _| ", "         ", "         "},
+		'S':  {"         ", "  _|_|_| ", "_|       ", "  _|_|   ", "      _| ", "_|_|_|   ", "         ", "         "},
+		'T':  {"           ", "_|_|_|_|_| ", "    _|     ", "    _|     ", "    _|     ", "    _|     ", "           ", "           "},
+		'U':  {"         ", "_|    _| ", "_|    _| ", "_|    _| ", "_|    _| ", "  _|_|   ", "         ", "         "},
+		'V':  {"           ", "_|      _| ", "_|      _| ", "_|      _| ", "  _|  _|   ", "    _|     ", "           ", "           "},
+		'W':  {"               ", "_|          _| ", "_|          _| ", "_|    _|    _| ", "  _|  _|  _|   ", "    _|  _|     ", "               ", "               "},
+		'X':  {"           ", "_|      _| ", "  _|  _|   ", "    _|     ", "  _|  _|   ", "_|      _| ", "           ", "           "},
+		'Y':  {"           ", "_|      _| ", "  _|  _|   ", "    _|     ", "    _|     ", "    _|     ", "           ", "           "},
+		'Z':  {"           ", "_|_|_|_|_| ", "      _|   ", "    _|     ", "  _|       ", "_|_|_|_|_| ", "           ", "           "},
+		'[':  {"_|_| ", "_|   ", "_|   ", "_|   ", "_|   ", "_|   ", "_|_| ", "     "},
+		'\\': {"           ", "_|         ", "  _|       ", "    _|     ", "      _|   ", "        _| ", "           ", "           "},
+		']':  {"_|_| ", "  _| ", "  _| ", "  _| ", "  _| ", "  _| ", "_|_| ", "     "},
+		'^':  {"  _|   ", "_|  _| ", "       ", "       ", "       ", "       ", "       ", "       "},
+		'_':  {"           ", "           ", "           ", "           ", "           ", "           ", "           ", "_|_|_|_|_| "},
+		'`':  {"_|   ", "  _| ", "     ", "     ", "     ", "     ", "     ", "     "},
+		'a':  {"         ", "         ", "  _|_|_| ", "_|    _| ", "_|    _| ", "  _|_|_| ", "         ", "         "},
+		'b':  {"         ", "_|       ", "_|_|_|   ", "_|    _| ", "_|    _| ", "_|_|_|   ", "         ", "         "},
+		'c':  {"         ", "         ", "  _|_|_| ", "_|       ", "_|       ", "  _|_|_| ", "         ", "         "},
+		'd':  {"         ", "      _| ", "  _|_|_| ", "_|    _| ", "_|    _| ", "  _|_|_| ", "         ", "         "},
+		'e':  {"         ", "         ", "  _|_|   ", "_|_|_|_| ", "_|       ", "  _|_|_| ", "         ", "         "},
+		'f':  {"         ", "    _|_| ", "  _|     ", "_|_|_|_| ", "  _|     ", "  _|     ", "         ", "         "},
+		'g':  {"         ", "         ", "  _|_|_| ", "_|    _| ", "_|    _| ", "  _|_|_| ", "      _| ", "  _|_|   "},
+		'h':  {"         ", "_|       ", "_|_|_|   ", "_|    _| ", "_|    _| ", "_|    _| ", "         ", "         "},
+		'i':  {"   ", "_| ", "   ", "_| ", "_| ", "_| ", "   ", "   "},
+		'j':  {"     ", "  _| ", "     ", "  _| ", "  _| ", "  _| ", "  _| ", "_|   "},
+		'k':  {"         ", "_|       ", "_|  _|   ", "_|_|     ", "_|  _|   ", "_|    _| ", "         ", "         "},
+		'l':  {"   ", "_| ", "_| ", "_| ", "_| ", "_| ", "   ", "   "},
+		'm':  {"               ", "               ", "_|_|_|  _|_|   ", "_|    _|    _| ", "_|    _|    _| ", "_|    _|    _| ", "               ", "               "},
+		'n':  {"         ", "         ", "_|_|_|   ", "_|    _| ", "_|    _| ", "_|    _| ", "         ", "         "},
+		'o':  {"         ", "         ", "  _|_|   ", "_|    _| ", "_|    _| ", "  _|_|   ", "         ", "         "},
+		'p':  {"         ", "         ", "_|_|_|   ", "_|    _| ", "_|    _| ", "_|_|_|   ", "_|       ", "_|       "},
+		'q':  {"         ", "         ", "  _|_|_| ", "_|    _| ", "_|    _| ", "  _|_|_| ", "      _| ", "      _| "},
+		'r':  {"         ", "         ", "_|  _|_| ", "_|_|     ", "_|       ", "_|       ", "         ", "         "},
+		's':  {"         ", "         ", "  _|_|_| ", "_|_|     ", "    _|_| ", "_|_|_|   ", "         ", "         "},
+		't':  {"         ", "  _|     ", "_|_|_|_| ", "  _|     ", "  _|     ", "    _|_| ", "         ", "         "},
+		'u':  {"         ", "         ", "_|    _| ", "_|    _| ", "_|    _| ", "  _|_|_| ", "         ", "         "},
+		'v':  {"           ", "           ", "_|      _| ", "_|      _| ", "  _|  _|   ", "    _|     ", "           ", "           "},
+		'w':  {"                   ", "                   ", "_|      _|      _| ", "_|      _|      _| ", "  _|  _|  _|  _|   ", "    _|      _|     ", "                   ", "                   "},
+		'x':  {"         ", "         ", "_|    _| ", "  _|_|   ", "_|    _| ", "_|    _| ", "         ", "         "},
+		'y':  {"         ", "         ", "_|    _| ", "_|    _| ", "_|    _| ", "  _|_|_| ", "      _| ", "  _|_|   "},
+		'z':  {"         ", "         ", "_|_|_|_| ", "    _|   ", "  _|     ", "_|_|_|_| ", "         ", "         "},
+		'{':  {"    _| ", "  _|   ", "  _|   ", "_|     ", "  _|   ", "  _|   ", "    _| ", "       "},
+		'|':  {"_| ", "_| ", "_| ", "_| ", "_| ", "_| ", "_| ", "_| "},
+		'}':  {"_|     ", "  _|   ", "  _|   ", "    _| ", "  _|   ", "  _|   ", "_|     ", "       "},
+		'~':  {"  _|  _| ", "_|  _|   ", "         ", "         ", "         ", "         ", "         ", "         "},
+	},
+	"testdata/thinkertoy.txt": {
+		' ':  {"      ", "      ", "      ", "      ", "      ", "      ", "      ", "      "},
+		'!':  {"  ", "o ", "| ", "o ", "  ", "O ", "  ", "  "},
+		'"':  {"o o ", "| | ", "    ", "    ", "    ", "    ", "    ", "    "},
+		'#':  {"      ", " | |  ", "-O-O- ", " | |  ", "-O-O- ", " | |  ", "      ", "      "},
+		'$':  {"  | |   ", " -O-O-  ", "o | |   ", " -O-O-  ", "  | | o ", " -O-O-  ", "  | |   ", "        "},
+		'%':  {"      ", "    O ", "o  /  ", "  /   ", " /  o ", "O     ", "      ", "      "},
+		'&':  {"     ", "     ", "  o  ", " /|  ", "o-O- ", "  |  ", "     ", "     "},
+		'\'': {"o ", "| ", "  ", "  ", "  ", "  ", "  ", "  "},
+		'(':  {"   ", " / ", "o  ", "|  ", "o  ", " \\ ", "   ", "   "},
+		')':  {"   ", "\\  ", " o ", " | ", " o ", "/  ", "   ", "   "},
+		'*':  {"      ", "o | o ", " \\|/  ", "--O-- ", " /|\\  ", "o | o ", "      ", "      "},
+		'+':  {"    ", "    ", " |  ", "-o- ", " |  ", "    ", "    ", "    "},
+		',':  {"  ", "  ", "  ", "  ", "  ", "o ", "| ", "  "},
+		'-':  {"    ", "    ", "    ", "    ", "o-o ", "    ", "    ", "    "},
+		'.':  {"  ", "  ", "  ", "  ", "  ", "O ", "  ", "  "},
+		'/':  {"      ", "    o ", "   /  ", "  o   ", " /    ", "o     ", "      ", "      "},
+		'0':  {"      ", " o-o  ", "o  /o ", "| / | ", "o/  o ", " o-o  ", "      ", "      "},
+		'1':  {"      ", "  0   ", " /|   ", "o |   ", "  |   ", "o-o-o ", "      ", "      "},
+		'2':  {"     ", " --  ", "o  o ", "  /  ", " /   ", "o--o ", "     ", "     "},
+		'3':  {"     ", "o-o  ", "   | ", " oo  ", "   | ", "o-o  ", "     ", "     "},
+		'4':  {"     ", "o  o ", "|  | ", "o--O ", "   | ", "   o ", "     ", "     "},
+		'5':  {"     ", "o--o ", "|    ", "o-o  ", "   | ", "o-o  ", "     ", "     "},
+		'6':  {"      ", "  o   ", " /    ", "O--o  ", "o   | ", " o-o  ", "      ", "      "},
+		'7':  {"      ", "o---o ", "   /  ", "  o   ", "  |   ", "  o   ", "      ", "      "},
+		'8':  {"      ", " o-o  ", "|   | ", " o-o  ", "|   | ", " o-o  ", "      ", "      "},
+		'9':  {"      ", " o-o  ", "|   o ", " o--O ", "   /  ", "  o   ", "      ", "      "},
+		':':  {"  ", "  ", "O ", "  ", "O ", "  ", "  ", "  "},
+		';':  {"  ", "  ", "o ", "  ", "o ", "| ", "  ", "  "},
+		'<':  {"    ", "  o ", " /  ", "O   ", " \\  ", "  o ", "    ", "    "},
+		'=':  {"     ", "     ", "     ", "o--o ", "o--o ", "     ", "     ", "     "},
+		'>':  {"    ", "o   ", " \\  ", "  O ", " /  ", "o   ", "    ", "    "},
+		'?':  {"      ", " o-o  ", "o   o ", "   /  ", "  o   ", "      ", "  O   ", "      "},
+		'@':  {"      ", "  o   ", " / \\  ", "o O-o ", " \\    ", "  o-  ", "      ", "      "},
+		'A':  {"      ", "  O   ", " / \\  ", "o---o ", "|   | ", "o   o ", "      ", "      "},
+		'B':  {"      ", "o--o  ", "|   | ", "O--o  ", "|   | ", "o--o  ", "      ", "      "},
+		'C':  {"      ", "  o-o ", " /    ", "O     ", " \\    ", "  o-o ", "      ", "      "},
+		'D':  {"      ", "o-o   ", "|  \\  ", "|   O ", "|  /  ", "o-o   ", "      ", "      "},
+		'E':  {"     ", "o--o ", "|    ", "O-o  ", "|    ", "o--o ", "     ", "     "},
+		'F':  {"     ", "o--o ", "|    ", "O-o  ", "|    ", "o    ", "     ", "     "},
+		'G':  {"      ", " o-o  ", "o     ", "|  -o ", "o   | ", " o-o  ", "      ", "      "},
+		'H':  {"     ", "o  o ", "|  | ", "O--O ", "|  | ", "o  o ", "     ", "     "},
+		'I':  {"      ", "o-O-o ", "  |   ", "  |   ", "  |   ", "o-O-o ", "      ", "      "},
+		'J':  {"      ", "    o ", "    | ", "    | ", "\\   o ", " o-o  ", "      ", "      "},
+		'K':  {"     ", "o  o ", "| /  ", "OO   ", "| \\  ", "o  o ", "     ", "     "},
+		'L':  {"      ", "o     ", "|     ", "|     ", "|     ", "O---o ", "      ", "      "},
+		'M':  {"      ", "o   o ", "|\\ /| ", "| O | ", "|   | ", "o   o ", "      ", "      "},
+		'N':  {"      ", "o   o ", "|\\  | ", "| \\ | ", "|  \\| ", "o   o ", "      ", "      "},
+		'O':  {"      ", " o-o  ", "o   o ", "|   | ", "o   o ", " o-o  ", "      ", "      "},
+		'P':  {"      ", "o--o  ", "|   | ", "O--o  ", "|     ", "o     ", "      ", "      "},
+		'Q':  {"      ", " o-o  ", "o   o ", "|   | ", "o   O ", " o-O\\ ", "      ", "      "},
+		'R':  {"      ", "o--o  ", "|   | ", "O-Oo  ", "|  \\  ", "o   o ", "      ", "      "},
+		'S':  {"      ", " o-o  ", "|     ", " o-o  ", "    | ", "o--o  ", "      ", "      "},
+		'T':  {"      ", "o-O-o ", "  |   ", "  |   ", "  |   ", "  o   ", "      ", "      "},
+		'U':  {"      ", "o   o ", "|   | ", "|   | ", "|   | ", " o-o  ", "      ", "      "},
+		'V':  {"      ", "o   o ", "|   | ", "o   o ", " \\ /  ", "  o   ", "      ", "      "},
+		'W':  {"          ", "o       o ", "|       | ", "o   o   o ", " \\ / \\ /  ", "  o   o   ", "          ", "          "},
+		'X':  {"      ", "o   o ", " \\ /  ", "  O   ", " / \\  ", "o   o ", "      ", "      "},
+		'Y':  {"      ", "o   o ", " \\ /  ", "  O   ", "  |   ", "  o   ", "      ", "      "},
+		'Z':  {"      ", "o---o ", "   /  ", " -O-  ", " /    ", "o---o ", "      ", "      "},
+		'[':  {"    ", "O-o ", "|   ", "|   ", "|   ", "O-o ", "    ", "    "},
+		'\\': {"      ", "o     ", " \\    ", "  o   ", "   \\  ", "    o ", "      ", "      "},
+		']':  {"    ", "o-O ", "  | ", "  | ", "  | ", "o-O ", "    ", "    "},
+		'^':  {"    ", " o  ", "/ \\ ", "    ", "    ", "    ", "    ", "    "},
+		'_':  {"      ", "      ", "      ", "      ", "      ", "o---o ", "      ", "      "},
+		'`':  {"  ", "0 ", "| ", "  ", "  ", "  ", "  ", "  "},
+		'a':  {"     ", "     ", "     ", " oo  ", "| |  ", "o-o- ", "     ", "     "},
+		'b':  {"     ", "o    ", "|    ", "O-o  ", "|  | ", "o-o  ", "     ", "     "},
+		'c':  {"     ", "     ", "     ", " o-o ", "|    ", " o-o ", "     ", "     "},
+		'd':  {"     ", "   o ", "   | ", " o-O ", "|  | ", " o-o ", "     ", "     "},
+		'e':  {"    ", "    ", "    ", "o-o ", "|-' ", "o-o ", "    ", "    "},
+		'f':  {"     ", " o-o ", " |   ", "-O-  ", " |   ", " o   ", "     ", "     "},
+		'g':  {"     ", "     ", "     ", "o--o ", "|  | ", "o--O ", "   | ", "o--o "},
+		'h':  {"     ", "o    ", "|    ", "O--o ", "|  | ", "o  o ", "     ", "     "},
+		'i':  {"  ", "  ", "o ", "  ", "| ", "| ", "  ", "  "},
+		'j':  {"      ", "      ", "    o ", "      ", "    o ", "    | ", "o   o ", " o-o  "},
+		'k':  {"     ", "o    ", "| /  ", "OO   ", "| \\  ", "o  o ", "     ", "     "},
+		'l':  {"  ", "o ", "| ", "| ", "| ", "o ", "  ", "  "},
+		'm':  {"      ", "      ", "      ", "o-O-o ", "| | | ", "o o o ", "      ", "      "},
+		'n':  {"     ", "     ", "     ", "o-o  ", "|  | ", "o  o ", "     ", "     "},
+		'o':  {"    ", "    ", "    ", "o-o ", "| | ", "o-o ", "    ", "    "},
+		'p':  {"     ", "     ", "     ", "o-o  ", "|  | ", "O-o  ", "|    ", "o    "},
+		'q':  {"     ", "     ", "     ", " o-o ", "|  | ", " o-O ", "   | ", "   o "},
+		'r':  {"    ", "    ", "    ", "o-o ", "|   ", "o   ", "    ", "    "},
+		's':  {"    ", "    ", "    ", "o-o ", " \\  ", "o-o ", "    ", "    "},
+		't':  {"    ", " o  ", " |  ", "-o- ", " |  ", " o  ", "    ", "    "},
+		'u':  {"     ", "     ", "     ", "o  o ", "|  | ", "o--o ", "     ", "     "},
+		'v':  {"      ", "      ", "      ", "o   o ", " \\ /  ", "  o   ", "      ", "      "},
+		'w':  {"          ", "          ", "          ", "o   o   o ", " \\ / \\ /  ", "  o   o   ", "          ", "          "},
+		'x':  {"    ", "    ", "    ", "\\ / ", " o  ", "/ \\ ", "    ", "    "},
+		'y':  {"     ", "     ", "     ", "o  o ", "|  | ", "o--O ", "   | ", "o--o "},
+		'z':  {"    ", "    ", "    ", "o-o ", " /  ", "o-o ", "    ", "    "},
+		'{':  {"      ", "  o-o ", "  |   ", "o-O   ", "  |   ", "  o-o ", "      ", "      "},
+		'|':  {"  ", "o ", "| ", "o ", "| ", "o ", "  ", "  "},
+		'}':  {"      ", "o-o   ", "  |   ", "  O-o ", "  |   ", "o-o   ", "      ", "      "},
+		'~':  {" o_ / ", "/  o  ", "      ", "      ", "      ", "      ", "      ", "      "},
+	},
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"ascii-art-fs/internal/parser"
+)
+
+func TestPrecompiledBannersMatchText(t *testing.T) {
+	builtin := make(map[string]bool)
+	for _, spec := range builtinBanners {
+		builtin[spec.Path] = true
+		want, err := parser.LoadBanner(bannerFS, spec.Path)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", spec.Path, err)
+		}
+		if !reflect.DeepEqual(precompiledBanners[spec.Path], want) {
+			t.Errorf("precompiled %s is out of date; run go generate in cmd/ascii-art", spec.Path)
+		}
+	}
+	for path := range precompiledBanners {
+		if !builtin[path] {
+			t.Errorf("precompiled %s is not a built-in banner", path)
+		}
+	}
+}
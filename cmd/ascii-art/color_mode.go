@@ -1,8 +1,10 @@
 package main
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -16,12 +18,21 @@ import (
 // runColorMode handles execution when the --color flag is detected.
 //
 // The function validates color mode arguments, parses the color specification,
-// loads the banner, and renders ASCII art with ANSI color codes applied.
+// loads the banner, and renders ASCII art with ANSI color codes applied. With an
+// --effect, each rendered line is decorated and the decoration takes the
+// --effect-color instead of the text color. Lines longer than --width are
+// wrapped, with the color matches found before wrapping, and --align pads
+// each block before it is printed. With --layout=kerning or smushing, cells
+// are colored by the character whose glyph drew them. The text is read and rendered one
+// line at a time, from standard input when the text argument is "-", except with
+// --vertical, which needs the whole text to lay out its columns.
 // It exits with appropriate error codes if validation or rendering fails.
 //
 // Parameters:
 //   - args: Command-line arguments including os.Args[0].
-func runColorMode(args []string) {
+//   - opts: The extended options; the transform, effect, effect color, width,
+//     alignment, layout, spacing and vertical layout apply.
+func runColorMode(args []string, opts cliOptions) {
 	if err := flagparser.ParseArgs(args); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(exitCodeUsageError)
@@ -39,43 +50,139 @@ func runColorMode(args []string) {
 		os.Exit(exitCodeColorError)
 	}
 
-	bannerPath, err := GetBannerPath(bannerName)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, "Error:", err)
-		os.Exit(exitCodeUsageError)
-	}
+	effectCode := effectColorOrExit(opts.effectColor)
+	charMap, layout := loadLayoutBannerOrExit(bannerName, opts)
 
-	charMap, err := parser.LoadBanner(GetBannerFS(), bannerPath)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading banner file: %v\n", err)
-		os.Exit(exitCodeBannerError)
+	colorCode := color.ANSI(rgb)
+	if layout.Vertical {
+		if opts.effect != nil {
+			fmt.Fprintf(os.Stderr, "%s cannot be combined with %s in color mode\n", verticalFlag, strings.TrimSuffix(effectFlag, "="))
+			os.Exit(exitCodeUsageError)
+		}
+		printVertical(text, substring, charMap, layout, colorCode)
+		return
 	}
+	reader := bufio.NewReader(textReader(text))
 
-	colorCode := color.ANSI(rgb)
-	lines := strings.Split(text, "\n")
+	// Every block but the first is preceded by the line spacing.
+	first := true
+	spacing := func() {
+		if !first {
+			fmt.Print(strings.Repeat("\n", layout.LineSpacing))
+		}
+		first = false
+	}
 
-	for i, line := range lines {
+	for {
+		line, readErr := reader.ReadString('\n')
+		if readErr != nil && readErr != io.EOF {
+			fmt.Fprintf(os.Stderr, "Error reading text: %v\n", readErr)
+			os.Exit(exitCodeRenderError)
+		}
+		if readErr == io.EOF && line == "" {
+			return
+		}
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
 		if line == "" {
-			if i < len(lines)-1 {
-				fmt.Println()
-			}
+			spacing()
+			fmt.Println()
 			continue
 		}
 
-		art, err := renderer.ASCII(line, charMap)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error rendering text: %v\n", err)
-			os.Exit(exitCodeRenderError)
+		// Matches are found in the whole line, so a match split by wrapping
+		// stays colored on both sides of the break. Blocks are colored before
+		// their indent is added, so the ANSI codes never count toward the width.
+		positions := coloring.Positions(line, substring)
+		for _, b := range renderer.Place(line, charMap, layout) {
+			spacing()
+			indent := strings.Repeat(" ", b.Indent)
+			b.Indent = 0
+			artLines, err := renderer.RenderBlock(line, b, charMap)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error rendering text: %v\n", err)
+				os.Exit(exitCodeRenderError)
+			}
+
+			partPositions := positions[b.Start:b.End]
+			widths := b.Widths(line, charMap)
+			var colored []string
+			switch {
+			case opts.effect != nil:
+				mask := coloring.ColumnMask(partPositions, widths)
+				colored = decorate(artLines, opts.effect, mask, colorCode, effectCode)
+			case layout.Layout != renderer.LayoutFull || layout.LetterSpacing < 0:
+				// Kerned, smushed and negatively spaced glyphs overlap, so cells are colored by
+				// the character that drew them rather than by column.
+				colored = coloring.ColorCells(artLines, ownerCodes(b.Owners(line, charMap), positions, colorCode))
+			default:
+				colored = coloring.ApplyPositions(artLines, partPositions, colorCode, widths)
+			}
+
+			for _, cl := range colored {
+				fmt.Println(indent + cl)
+			}
 		}
+		if readErr == io.EOF {
+			return
+		}
+	}
+}
 
-		artLines := strings.Split(strings.TrimSuffix(art, "\n"), "\n")
-		widths := parser.CharWidths(line, charMap)
-		colored := coloring.ApplyColor(artLines, line, substring, colorCode, widths)
+// printVertical renders the whole text vertically and prints it with every
+// cell colored by the character whose glyph drew it. Matches are found in
+// each input line separately, as in horizontal color mode.
+//
+// Parameters:
+//   - text: The text argument; "-" reads standard input.
+//   - substring: The substring to color, or "" to color the whole text.
+//   - charMap: The banner.
+//   - layout: The render options, with Vertical set.
+//   - colorCode: The code of matched characters.
+func printVertical(text, substring string, charMap parser.Banner, layout renderer.Options, colorCode string) {
+	input, err := io.ReadAll(textReader(text))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading text: %v\n", err)
+		os.Exit(exitCodeRenderError)
+	}
+	artLines, owners, err := renderer.Vertical(string(input), charMap, layout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error rendering text: %v\n", err)
+		os.Exit(exitCodeRenderError)
+	}
 
-		for _, cl := range colored {
-			fmt.Println(cl)
+	positions := make([]bool, 0, len(input))
+	for _, line := range strings.SplitAfter(string(input), "\n") {
+		positions = append(positions, coloring.Positions(strings.TrimSuffix(line, "\n"), substring)...)
+		if strings.HasSuffix(line, "\n") {
+			positions = append(positions, false)
+		}
+	}
+	for _, cl := range coloring.ColorCells(artLines, ownerCodes(owners, positions, colorCode)) {
+		fmt.Println(cl)
+	}
+}
+
+// ownerCodes returns the color code of every cell of a block from the
+// character that drew it.
+//
+// Parameters:
+//   - owners: The owner of every cell, from renderer.Block.Owners.
+//   - positions: Whether each byte of the line is part of a match.
+//   - colorCode: The code of matched characters.
+//
+// Returns:
+//   - The code of every cell, "" for cells left uncolored.
+func ownerCodes(owners [][]int, positions []bool, colorCode string) [][]string {
+	codes := make([][]string, len(owners))
+	for y, row := range owners {
+		codes[y] = make([]string, len(row))
+		for x, owner := range row {
+			if owner >= 0 && positions[owner] {
+				codes[y][x] = colorCode
+			}
 		}
 	}
+	return codes
 }
 
 // hasColorFlag checks whether the first user argument uses the --color flag.
@@ -0,0 +1,541 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"ascii-art-fs/internal/bitmapfont"
+	"ascii-art-fs/internal/convert"
+	"ascii-art-fs/internal/glyphdiff"
+	"ascii-art-fs/internal/lint"
+	"ascii-art-fs/internal/parser"
+)
+
+// subcommand runs a named tool mode of the CLI.
+//
+// It receives the arguments after the subcommand name and returns the process
+// exit code.
+type subcommand func(args []string, stdout, stderr io.Writer) int
+
+// subcommands maps subcommand names to their implementations.
+var subcommands = map[string]subcommand{
+	"lint":    runLint,
+	"convert": runConvert,
+	"import":  runImport,
+	"info":    runInfo,
+	"diff":    runDiff,
+}
+
+// lookupSubcommand returns the subcommand named by args[1].
+//
+// A subcommand name is only recognized when further arguments follow it, so
+// that `go run . lint` keeps rendering the word "lint" as before. Likewise, a
+// single argument naming a registered banner keeps the `"text" [banner]` form:
+// `go run . lint standard` renders "lint" in the standard banner. Such a banner
+// is passed to the subcommand after "--", as in `go run . info -- shadow`.
+//
+// Parameters:
+//   - args: Command-line arguments including the program name.
+//
+// Returns:
+//   - The subcommand, if any.
+//   - Whether args select a subcommand.
+func lookupSubcommand(args []string) (subcommand, bool) {
+	if len(args) < 3 {
+		return nil, false
+	}
+	cmd, ok := subcommands[args[1]]
+	if !ok {
+		return nil, false
+	}
+	if len(args) == 3 {
+		if _, isBanner := newBannerRegistry(bannerSearchPath(nil)).Lookup(args[2]); isBanner {
+			return nil, false
+		}
+	}
+	return cmd, true
+}
+
+// bannerDirList collects the values of a repeatable --banner-dir flag.
+type bannerDirList []string
+
+// String returns the directories separated like $PATH.
+func (l *bannerDirList) String() string {
+	return strings.Join(*l, string(filepath.ListSeparator))
+}
+
+// Set appends a directory, rejecting an empty value like the render path does.
+func (l *bannerDirList) Set(dir string) error {
+	if dir == "" {
+		return errors.New("empty directory")
+	}
+	*l = append(*l, dir)
+	return nil
+}
+
+// addBannerDirFlag defines the repeatable --banner-dir flag on a subcommand's
+// flag set, so that subcommands resolve banner names like the render path.
+//
+// Parameters:
+//   - flags: The subcommand's flag set.
+//
+// Returns:
+//   - A function that rebuilds the banner registry from the parsed directories,
+//     ASCII_ART_BANNER_PATH and the XDG data directory; call it after parsing.
+func addBannerDirFlag(flags *flag.FlagSet) func() {
+	var dirs bannerDirList
+	flags.Var(&dirs, "banner-dir", "extra banner directory searched before the built-in banners (repeatable)")
+	return func() {
+		setBannerDirs(bannerSearchPath(dirs))
+	}
+}
+
+// runLint implements `lint [--fix] <file>...`.
+//
+// Every diagnostic is printed as "path:line: severity: message". With --fix,
+// each file is normalized in place first and the remaining diagnostics of the
+// rewritten file are reported.
+//
+// Parameters:
+//   - args: The arguments after "lint".
+//   - stdout: The destination for diagnostics.
+//   - stderr: The destination for usage and I/O errors.
+//
+// Returns:
+//   - exitCodeUsageError for invalid arguments, exitCodeBannerError if any file
+//     could not be read or has errors, or 0 when all files are clean or only
+//     have warnings.
+func runLint(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	fix := flags.Bool("fix", false, "normalize the banner files in place")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: go run . lint [--fix] <banner.txt>...")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return exitCodeUsageError
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitCodeUsageError
+	}
+
+	exitCode := 0
+	for _, path := range flags.Args() {
+		diags, err := lintFile(path, *fix)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			exitCode = exitCodeBannerError
+			continue
+		}
+		for _, d := range diags {
+			fmt.Fprintln(stdout, d)
+		}
+		if lint.HasErrors(diags) {
+			exitCode = exitCodeBannerError
+		}
+	}
+	return exitCode
+}
+
+// lintFile lints one banner file, optionally fixing it in place first.
+//
+// Parameters:
+//   - path: The banner file path.
+//   - fix: Whether to rewrite the file with lint.Fix before checking it.
+//
+// Returns:
+//   - The diagnostics for the (possibly rewritten) file.
+//   - An error if the file cannot be read or written.
+func lintFile(path string, fix bool) ([]lint.Diagnostic, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read banner file: %w", err)
+	}
+
+	if fix {
+		fixed := lint.Fix(data)
+		if string(fixed) != string(data) {
+			info, err := os.Stat(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fix banner file: %w", err)
+			}
+			if err := os.WriteFile(path, fixed, info.Mode().Perm()); err != nil {
+				return nil, fmt.Errorf("failed to fix banner file: %w", err)
+			}
+		}
+		data = fixed
+	}
+
+	return lint.Check(path, data), nil
+}
+
+// runConvert implements `convert [--banner-dir=<dir>] [--to=<format>] <banner> <output>`.
+//
+// The banner may be a registered name or a banner file in any format the
+// parser reads; it may define only some glyphs. The output format is taken
+// from --to or else from the extension of the output file. An output of "-"
+// writes to stdout and requires --to. The banner's metadata is kept. Glyphs
+// that cannot be written exactly are reported as warnings on stderr.
+//
+// Parameters:
+//   - args: The arguments after "convert".
+//   - stdout: The destination for "-" output.
+//   - stderr: The destination for warnings, usage and errors.
+//
+// Returns:
+//   - exitCodeUsageError for invalid arguments, the banner error code from
+//     describeBannerError if the banner cannot be loaded, exitCodeBannerError
+//     if the output cannot be written, or 0.
+func runConvert(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("convert", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	to := flags.String("to", "", "output format: txt, flf or json (default: from the output file extension)")
+	useBannerDirs := addBannerDirFlag(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: go run . convert [--banner-dir=<dir>] [--to=<format>] <banner> <output>")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return exitCodeUsageError
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return exitCodeUsageError
+	}
+	input, output := flags.Arg(0), flags.Arg(1)
+
+	format, err := outputFormat(*to, output)
+	if err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return exitCodeUsageError
+	}
+
+	useBannerDirs()
+	charMap, warnings, err := loadPartialBanner(input)
+	for _, w := range warnings {
+		fmt.Fprintln(stderr, "Warning:", w)
+	}
+	if err != nil {
+		msg, code := describeBannerError(err)
+		fmt.Fprintln(stderr, msg)
+		return code
+	}
+	meta, err := loadBannerMetadata(input)
+	if err != nil {
+		msg, code := describeBannerError(err)
+		fmt.Fprintln(stderr, msg)
+		return code
+	}
+
+	return writeBanner(charMap, meta, format, output, stdout, stderr)
+}
+
+// writeBanner writes a banner in the given format and reports the glyphs that
+// could not be written exactly as warnings.
+//
+// Parameters:
+//   - charMap: The banner to write.
+//   - meta: The banner's metadata.
+//   - format: The output format.
+//   - output: The output file path, or "-" for stdout.
+//   - stdout: The destination for "-" output.
+//   - stderr: The destination for warnings and errors.
+//
+// Returns:
+//   - exitCodeBannerError if the output cannot be written, or 0.
+func writeBanner(charMap parser.Banner, meta parser.Metadata, format convert.Format, output string, stdout, stderr io.Writer) int {
+	var buf bytes.Buffer
+	problems, err := convert.Write(&buf, charMap, meta, format)
+	if err == nil {
+		if output == "-" {
+			_, err = stdout.Write(buf.Bytes())
+		} else {
+			err = os.WriteFile(output, buf.Bytes(), 0o644)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: failed to write %s: %v\n", output, err)
+		return exitCodeBannerError
+	}
+	for _, p := range problems {
+		fmt.Fprintln(stderr, "Warning:", p)
+	}
+	return 0
+}
+
+// runImport implements `import [--ink=<char>] [--half-blocks] [--to=<format>] <font> <output>`.
+//
+// The font is a BDF or PSF bitmap font, optionally gzip-compressed. Its
+// bitmaps are drawn with the ink character, or with half blocks that halve
+// the height, and written like the output of convert.
+//
+// Parameters:
+//   - args: The arguments after "import".
+//   - stdout: The destination for "-" output.
+//   - stderr: The destination for warnings, usage and errors.
+//
+// Returns:
+//   - exitCodeUsageError for invalid arguments, exitCodeBannerError if the
+//     font cannot be imported or the output cannot be written, or 0.
+func runImport(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	ink := flags.String("ink", string(bitmapfont.DefaultInk), "character drawn for set pixels")
+	halfBlocks := flags.Bool("half-blocks", false, "draw two pixel rows per line with block characters")
+	to := flags.String("to", "", "output format: txt, flf or json (default: from the output file extension)")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: go run . import [--ink=<char>] [--half-blocks] [--to=<format>] <font.bdf|font.psf> <output>")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return exitCodeUsageError
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return exitCodeUsageError
+	}
+	input, output := flags.Arg(0), flags.Arg(1)
+
+	inkRune, size := utf8.DecodeRuneInString(*ink)
+	if size == 0 || size != len(*ink) || inkRune == ' ' || unicode.IsControl(inkRune) {
+		fmt.Fprintf(stderr, "Error: --ink must be a single visible character, got %q\n", *ink)
+		return exitCodeUsageError
+	}
+	format, err := outputFormat(*to, output)
+	if err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return exitCodeUsageError
+	}
+
+	opts := bitmapfont.Options{Ink: inkRune, HalfBlocks: *halfBlocks}
+	charMap, err := bitmapfont.Load(os.DirFS(filepath.Dir(input)), filepath.Base(input), opts)
+	if err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return exitCodeBannerError
+	}
+	return writeBanner(charMap, parser.Metadata{}, format, output, stdout, stderr)
+}
+
+// outputFormat selects the format of a converted banner.
+//
+// Parameters:
+//   - name: The --to value, or "" to use the output file extension.
+//   - output: The output file path, or "-" for stdout.
+//
+// Returns:
+//   - The output format.
+//   - An error if the format is unknown or cannot be determined.
+func outputFormat(name, output string) (convert.Format, error) {
+	if name != "" {
+		return convert.ParseFormat(name)
+	}
+	if output == "-" {
+		return "", errors.New("--to is required when writing to stdout")
+	}
+	return convert.FormatForPath(output)
+}
+
+// runInfo implements `info [--banner-dir=<dir>] <banner>...`.
+//
+// For every banner, given by registered name or file path, the metadata
+// declared in the banner file is printed: name, author, license, height,
+// baseline, coverage and any other keys. Missing attribution is shown as
+// "not declared".
+//
+// Parameters:
+//   - args: The arguments after "info".
+//   - stdout: The destination for the metadata.
+//   - stderr: The destination for usage and errors.
+//
+// Returns:
+//   - exitCodeUsageError for invalid arguments, the banner error code from
+//     describeBannerError of the last banner that could not be read, or 0.
+func runInfo(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("info", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	useBannerDirs := addBannerDirFlag(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: go run . info [--banner-dir=<dir>] <banner>...")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return exitCodeUsageError
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitCodeUsageError
+	}
+
+	useBannerDirs()
+	exitCode := 0
+	for i, name := range flags.Args() {
+		meta, err := loadBannerMetadata(name)
+		if err != nil {
+			msg, code := describeBannerError(err)
+			fmt.Fprintln(stderr, msg)
+			exitCode = code
+			continue
+		}
+		if i > 0 {
+			fmt.Fprintln(stdout)
+		}
+		writeMetadata(stdout, name, meta)
+	}
+	return exitCode
+}
+
+// writeMetadata prints the metadata of one banner under its name.
+//
+// Parameters:
+//   - w: The destination.
+//   - name: The banner name or path as given on the command line.
+//   - meta: The banner's metadata.
+func writeMetadata(w io.Writer, name string, meta parser.Metadata) {
+	const notDeclared = "not declared"
+	orNotDeclared := func(value string) string {
+		if value == "" {
+			return notDeclared
+		}
+		return value
+	}
+	number := func(n int) string {
+		if n == 0 {
+			return notDeclared
+		}
+		return strconv.Itoa(n)
+	}
+	coverage := make([]string, len(meta.Coverage))
+	for i, cr := range meta.Coverage {
+		coverage[i] = cr.String()
+	}
+
+	fmt.Fprintln(w, name)
+	fmt.Fprintf(w, "  Name:     %s\n", orNotDeclared(meta.Name))
+	fmt.Fprintf(w, "  Author:   %s\n", orNotDeclared(meta.Author))
+	fmt.Fprintf(w, "  License:  %s\n", orNotDeclared(meta.License))
+	fmt.Fprintf(w, "  Height:   %s\n", number(meta.Height))
+	fmt.Fprintf(w, "  Baseline: %s\n", number(meta.Baseline))
+	fmt.Fprintf(w, "  Coverage: %s\n", orNotDeclared(strings.Join(coverage, ", ")))
+
+	keys := make([]string, 0, len(meta.Extra))
+	for key := range meta.Extra {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(w, "  %s: %s\n", key, meta.Extra[key])
+	}
+}
+
+// runDiff implements `diff [--banner-dir=<dir>] [--color=auto|always|never] <old> <new>`.
+//
+// Both banners, given by registered name or file path, are loaded like convert
+// loads them and compared glyph by glyph. Every glyph that differs is drawn old
+// and new side by side, with the changed cells highlighted in color or marked
+// with '^', followed by a summary of the glyph width changes. Like diff(1),
+// it exits with exitCodeDiffFound when the banners differ.
+//
+// Parameters:
+//   - args: The arguments after "diff".
+//   - stdout: The destination for the comparison.
+//   - stderr: The destination for warnings, usage and errors.
+//
+// Returns:
+//   - exitCodeUsageError for invalid arguments, the banner error code from
+//     describeBannerError if a banner cannot be loaded, exitCodeRenderError if
+//     the comparison cannot be written, exitCodeDiffFound if the banners differ,
+//     or 0 if they are identical.
+func runDiff(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	colorMode := flags.String("color", "auto", "highlight changed cells with colors: auto, always or never")
+	useBannerDirs := addBannerDirFlag(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: go run . diff [--banner-dir=<dir>] [--color=auto|always|never] <old banner> <new banner>")
+		fmt.Fprintln(stderr, "Exits with 0 if the banners are identical and 1 if they differ.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return exitCodeUsageError
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return exitCodeUsageError
+	}
+
+	var useColor bool
+	switch *colorMode {
+	case "always":
+		useColor = true
+	case "never":
+		useColor = false
+	case "auto":
+		useColor = supportsColor(stdout)
+	default:
+		fmt.Fprintf(stderr, "Error: --color must be auto, always or never, got %q\n", *colorMode)
+		return exitCodeUsageError
+	}
+
+	useBannerDirs()
+	pair := make([]parser.Banner, 2)
+	for i, name := range flags.Args() {
+		charMap, warnings, err := loadPartialBanner(name)
+		for _, w := range warnings {
+			fmt.Fprintln(stderr, "Warning:", w)
+		}
+		if err != nil {
+			msg, code := describeBannerError(err)
+			fmt.Fprintln(stderr, msg)
+			return code
+		}
+		pair[i] = charMap
+	}
+
+	changes := glyphdiff.Compare(pair[0], pair[1])
+	if err := glyphdiff.Write(stdout, changes, glyphdiff.Options{Color: useColor}); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitCodeRenderError
+	}
+	if len(changes) > 0 {
+		return exitCodeDiffFound
+	}
+	return 0
+}
+
+// supportsColor reports whether w is a terminal that should get ANSI colors:
+// NO_COLOR is unset and TERM is not "dumb".
+func supportsColor(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok || !isTerminal(f) {
+		return false
+	}
+	_, noColor := os.LookupEnv("NO_COLOR")
+	return !noColor && os.Getenv("TERM") != "dumb"
+}
@@ -0,0 +1,354 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"ascii-art-fs/internal/parser"
+)
+
+func TestLookupSubcommand(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"prog", "lint", "a.txt"}, true},
+		{[]string{"prog", "lint"}, false},
+		{[]string{"prog", "lint", "standard"}, false},
+		{[]string{"prog", "info", "shadow"}, false},
+		{[]string{"prog", "info", "--", "shadow"}, true},
+		{[]string{"prog", "info", "shadow", "standard"}, true},
+		{[]string{"prog", "convert", "standard", "out.flf"}, true},
+		{[]string{"prog", "hello", "standard"}, false},
+		{[]string{"prog"}, false},
+	}
+	for _, tt := range tests {
+		if _, ok := lookupSubcommand(tt.args); ok != tt.want {
+			t.Errorf("lookupSubcommand(%q) = %v, want %v", tt.args, ok, tt.want)
+		}
+	}
+}
+
+func TestRunLint(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		wantOut  string
+	}{
+		{"clean banner", []string{"testdata/standard.txt"}, 0, ""},
+		{"broken banner", []string{"testdata/corrupted.txt"}, exitCodeBannerError,
+			"testdata/corrupted.txt:10: error: glyph '!': separator line is not empty"},
+		{"missing file", []string{"testdata/missing.txt"}, exitCodeBannerError, ""},
+		{"no files", []string{"--fix"}, exitCodeUsageError, ""},
+		{"unknown flag", []string{"--nope", "testdata/standard.txt"}, exitCodeUsageError, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := runLint(tt.args, &stdout, &stderr)
+			if code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d\nstderr: %s", code, tt.wantCode, stderr.String())
+			}
+			if !strings.Contains(stdout.String(), tt.wantOut) {
+				t.Errorf("stdout missing %q, got:\n%s", tt.wantOut, stdout.String())
+			}
+		})
+	}
+}
+
+func TestRunLintFix(t *testing.T) {
+	data, err := os.ReadFile("testdata/standard.txt")
+	if err != nil {
+		t.Fatalf("failed to read standard.txt: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "messy.txt")
+	messy := strings.ReplaceAll(string(data), "\n", "\r\n") + "\r\n\r\n"
+	if err := os.WriteFile(path, []byte(messy), 0o644); err != nil {
+		t.Fatalf("failed to write banner: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := runLint([]string{path}, &stdout, &stderr); code != 0 {
+		t.Fatalf("warnings alone should not fail lint, got exit code %d", code)
+	}
+	if !strings.Contains(stdout.String(), "CRLF line ending") {
+		t.Fatalf("expected CRLF warnings, got:\n%s", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := runLint([]string{"--fix", path}, &stdout, &stderr); code != 0 || stdout.Len() != 0 {
+		t.Fatalf("lint --fix = %d with output:\n%s%s", code, stdout.String(), stderr.String())
+	}
+	fixed, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read fixed banner: %v", err)
+	}
+	if string(fixed) != string(data) {
+		t.Error("lint --fix should restore the original banner")
+	}
+}
+
+func TestRunConvert(t *testing.T) {
+	dir := t.TempDir()
+	want, err := parser.Load(os.DirFS("testdata"), "standard.txt")
+	if err != nil {
+		t.Fatalf("failed to load standard.txt: %v", err)
+	}
+
+	for _, name := range []string{"standard.flf", "standard.json", "copy.txt"} {
+		var stdout, stderr bytes.Buffer
+		out := filepath.Join(dir, name)
+		if code := runConvert([]string{"standard", out}, &stdout, &stderr); code != 0 {
+			t.Fatalf("convert to %s = %d, stderr: %s", name, code, stderr.String())
+		}
+		got, err := parser.Load(os.DirFS(dir), name)
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("%s does not load back as the standard banner: %v", name, err)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := runConvert([]string{"--to=json", "testdata/mini.flf", "-"}, &stdout, &stderr); code != 0 ||
+		!strings.HasPrefix(stdout.String(), "{") {
+		t.Errorf("convert to stdout = %d, output %q, stderr: %s", code, stdout.String(), stderr.String())
+	}
+
+	// Banners in --banner-dir directories convert by name.
+	userDir := t.TempDir()
+	standard, err := os.ReadFile("testdata/standard.txt")
+	if err != nil {
+		t.Fatalf("failed to read standard.txt: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(userDir, "mine.txt"), standard, 0o644); err != nil {
+		t.Fatalf("failed to write banner: %v", err)
+	}
+	defer setBannerDirs(nil)
+	stdout.Reset()
+	stderr.Reset()
+	if code := runConvert([]string{"--banner-dir=" + userDir, "--to=txt", "mine", "-"}, &stdout, &stderr); code != 0 ||
+		stdout.String() != string(standard) {
+		t.Errorf("convert from --banner-dir = %d, stderr: %s", code, stderr.String())
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+	}{
+		{"missing output", []string{"standard"}, exitCodeUsageError},
+		{"empty banner dir", []string{"--banner-dir=", "standard", "-"}, exitCodeUsageError},
+		{"unknown format", []string{"--to=bdf", "standard", "-"}, exitCodeUsageError},
+		{"stdout without format", []string{"standard", "-"}, exitCodeUsageError},
+		{"unknown extension", []string{"standard", filepath.Join(dir, "out.bin")}, exitCodeUsageError},
+		{"missing banner file", []string{"--to=txt", "testdata/missing.txt", "-"}, exitCodeBannerNotFound},
+		{"unwritable output", []string{"standard", filepath.Join(dir, "no", "such", "dir.txt")}, exitCodeBannerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := runConvert(tt.args, &stdout, &stderr); code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d\nstderr: %s", code, tt.wantCode, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunConvertReportsProblems(t *testing.T) {
+	dir := t.TempDir()
+	partial := filepath.Join(dir, "logo.txt")
+	if err := os.WriteFile(partial, []byte("\n  \n  \n\n!!\n!!"), 0o644); err != nil {
+		t.Fatalf("failed to write banner: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := runConvert([]string{"--to=flf", partial, "-"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "incomplete banner") ||
+		!strings.Contains(stderr.String(), `Warning: glyph '"' (U+0022): missing`) {
+		t.Errorf("expected warnings about missing glyphs, got:\n%s", stderr.String())
+	}
+}
+
+func TestRunImport(t *testing.T) {
+	dir := t.TempDir()
+	font := filepath.Join(dir, "tiny.bdf")
+	bdf := "STARTFONT 2.1\nFONTBOUNDINGBOX 2 2 0 0\nSTARTCHAR A\nENCODING 65\nDWIDTH 2 0\nBBX 2 2 0 0\nBITMAP\n80\nC0\nENDCHAR\nENDFONT\n"
+	if err := os.WriteFile(font, []byte(bdf), 0o644); err != nil {
+		t.Fatalf("failed to write font: %v", err)
+	}
+
+	out := filepath.Join(dir, "tiny.txt")
+	var stdout, stderr bytes.Buffer
+	if code := runImport([]string{"--ink=@", font, out}, &stdout, &stderr); code != 0 {
+		t.Fatalf("import = %d, stderr: %s", code, stderr.String())
+	}
+	got, _, err := parser.LoadPartial(os.DirFS(dir), "tiny.txt")
+	if err != nil || !reflect.DeepEqual(got['A'], []string{"@ ", "@@"}) {
+		t.Errorf("imported 'A' = %q, %v", got['A'], err)
+	}
+	if !strings.Contains(stderr.String(), "Warning: glyph ' ' (U+0020): missing") {
+		t.Errorf("expected warnings about glyphs the font lacks, got:\n%s", stderr.String())
+	}
+
+	stdout.Reset()
+	if code := runImport([]string{"--half-blocks", "--to=json", font, "-"}, &stdout, &stderr); code != 0 ||
+		!strings.Contains(stdout.String(), `"█▄"`) {
+		t.Errorf("half-block import = %d, output %q", code, stdout.String())
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+	}{
+		{"missing output", []string{font}, exitCodeUsageError},
+		{"long ink", []string{"--ink=ab", font, "-"}, exitCodeUsageError},
+		{"space ink", []string{"--ink= ", font, "-"}, exitCodeUsageError},
+		{"missing font", []string{"--to=txt", filepath.Join(dir, "missing.psf"), "-"}, exitCodeBannerError},
+		{"not a font", []string{"--to=txt", "testdata/standard.txt", "-"}, exitCodeBannerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := runImport(tt.args, &stdout, &stderr); code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d\nstderr: %s", code, tt.wantCode, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunInfo(t *testing.T) {
+	dir := t.TempDir()
+	standard, err := os.ReadFile("testdata/standard.txt")
+	if err != nil {
+		t.Fatalf("failed to read standard.txt: %v", err)
+	}
+	header := "---\nname: Licensed\nauthor: Jane Doe\nlicense: MIT\nheight: 8\nfoundry: Example\n---\n"
+	licensed := filepath.Join(dir, "licensed.txt")
+	if err := os.WriteFile(licensed, append([]byte(header), standard...), 0o644); err != nil {
+		t.Fatalf("failed to write banner: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := runInfo([]string{licensed, "standard"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr.String())
+	}
+	for _, want := range []string{
+		licensed + "\n  Name:     Licensed\n  Author:   Jane Doe\n  License:  MIT\n  Height:   8\n",
+		"  foundry: Example\n",
+		"standard\n  Name:     not declared\n",
+	} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, stdout.String())
+		}
+	}
+
+	// Banners in --banner-dir directories are described by name.
+	defer setBannerDirs(nil)
+	stdout.Reset()
+	stderr.Reset()
+	if code := runInfo([]string{"--banner-dir=" + dir, "licensed"}, &stdout, &stderr); code != 0 ||
+		!strings.Contains(stdout.String(), "Author:   Jane Doe") {
+		t.Errorf("info from --banner-dir = %d, output %q, stderr: %s", code, stdout.String(), stderr.String())
+	}
+
+	// Converting keeps the metadata.
+	converted := filepath.Join(dir, "converted.json")
+	if code := runConvert([]string{licensed, converted}, &stdout, &stderr); code != 0 {
+		t.Fatalf("convert exit code = %d, stderr: %s", code, stderr.String())
+	}
+	stdout.Reset()
+	stderr.Reset()
+	if code := runInfo([]string{converted}, &stdout, &stderr); code != 0 ||
+		!strings.Contains(stdout.String(), "Author:   Jane Doe\n  License:  MIT\n") {
+		t.Errorf("info of the converted banner = %d, output %q, stderr: %s", code, stdout.String(), stderr.String())
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	if code := runInfo([]string{"nosuchbanner"}, &stdout, &stderr); code != exitCodeUsageError {
+		t.Errorf("unknown banner exit code = %d, stderr: %s", code, stderr.String())
+	}
+}
+
+func TestRunDiff(t *testing.T) {
+	standard, err := os.ReadFile("testdata/standard.txt")
+	if err != nil {
+		t.Fatalf("failed to read standard.txt: %v", err)
+	}
+	// Widen one row of 'A' by a column.
+	lines := strings.Split(string(standard), "\n")
+	lines[int('A'-' ')*9+3] += "#"
+	edited := filepath.Join(t.TempDir(), "edited.txt")
+	if err := os.WriteFile(edited, []byte(strings.Join(lines, "\n")), 0o644); err != nil {
+		t.Fatalf("failed to write banner: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		want     []string
+	}{
+		{
+			name: "identical",
+			args: []string{"standard", "testdata/standard.txt"},
+			want: []string{"No glyph differences."},
+		},
+		{
+			name:     "markers",
+			wantCode: exitCodeDiffFound,
+			args:     []string{"standard", edited},
+			want: []string{
+				"'A' (U+0041) changed, width 11 -> 12\n",
+				"│ changes\n",
+				"^\n",
+				"Summary: 1 changed, 0 added, 0 removed\n",
+				"  'A' (U+0041): 11 -> 12 (+1)\n",
+			},
+		},
+		{
+			name:     "colors",
+			wantCode: exitCodeDiffFound,
+			args:     []string{"--color=always", "standard", edited},
+			want:     []string{"\033[42m#"},
+		},
+		{
+			name:     "banner directory",
+			wantCode: exitCodeDiffFound,
+			args:     []string{"--banner-dir=" + filepath.Dir(edited), "standard", "edited"},
+			want:     []string{"Summary: 1 changed, 0 added, 0 removed\n"},
+		},
+		{
+			name:     "invalid color mode",
+			args:     []string{"--color=sometimes", "standard", edited},
+			wantCode: exitCodeUsageError,
+		},
+		{
+			name:     "missing banner",
+			args:     []string{"standard", "missing.txt"},
+			wantCode: exitCodeBannerNotFound,
+		},
+	}
+	defer setBannerDirs(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := runDiff(tt.args, &stdout, &stderr); code != tt.wantCode {
+				t.Fatalf("exit code = %d, want %d\nstderr: %s", code, tt.wantCode, stderr.String())
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, stdout.String())
+				}
+			}
+		})
+	}
+}
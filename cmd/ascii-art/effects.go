@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"ascii-art-fs/internal/color"
+	"ascii-art-fs/internal/coloring"
+	"ascii-art-fs/internal/effect"
+)
+
+// effectColorOrExit converts the --effect-color specification to an ANSI code.
+// It exits with exitCodeColorError if the color is invalid.
+//
+// Parameters:
+//   - spec: The --effect-color value, or "" when the option was not given.
+//
+// Returns:
+//   - The ANSI escape code, or "" when spec is empty.
+func effectColorOrExit(spec string) string {
+	if spec == "" {
+		return ""
+	}
+	rgb, err := color.Parse(spec)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --effect-color: %v\n", err)
+		os.Exit(exitCodeColorError)
+	}
+	return color.ANSI(rgb)
+}
+
+// decorate applies an effect to rendered ASCII art and colors the result.
+//
+// Text and decoration are colored separately: ink cells in the columns selected
+// by textMask take textCode, and cells added by the effect take effectCode.
+//
+// Parameters:
+//   - art: The rendered ASCII art lines.
+//   - e: The effect to apply.
+//   - textMask: The columns of the undecorated art to color as text, or nil.
+//   - textCode: ANSI escape code for the text, or "".
+//   - effectCode: ANSI escape code for the decoration, or "" to leave it uncolored.
+//
+// Returns:
+//   - The decorated, colored lines.
+func decorate(art []string, e effect.Effect, textMask []bool, textCode, effectCode string) []string {
+	decorated := e(art)
+	if textCode == "" && effectCode == "" {
+		return decorated.Lines
+	}
+
+	codes := make([][]string, len(decorated.Layers))
+	for y, layers := range decorated.Layers {
+		codes[y] = make([]string, len(layers))
+		for x, layer := range layers {
+			switch layer {
+			case effect.Ink:
+				if col := x - decorated.X; col >= 0 && col < len(textMask) && textMask[col] {
+					codes[y][x] = textCode
+				}
+			case effect.Decoration:
+				codes[y][x] = effectCode
+			}
+		}
+	}
+	return coloring.ColorCells(decorated.Lines, codes)
+}
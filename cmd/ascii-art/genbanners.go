@@ -0,0 +1,77 @@
+//go:build ignore
+
+// genbanners parses banner files and writes them as Go source, so that the CLI
+// can use the embedded banners without parsing text at startup.
+//
+// Usage (run by go generate from cmd/ascii-art):
+//
+//	go run genbanners.go -o banners_gen.go testdata/standard.txt ...
+//
+// The banner files stay the source of truth: TestPrecompiledBannersMatchText
+// fails when the generated file is out of date.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"slices"
+
+	"ascii-art-fs/internal/parser"
+)
+
+func main() {
+	out := flag.String("o", "banners_gen.go", "output file")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		log.Fatal("usage: go run genbanners.go -o <output.go> <banner.txt>...")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by genbanners.go; DO NOT EDIT.\n\n")
+	buf.WriteString("package main\n\n")
+	buf.WriteString("import \"ascii-art-fs/internal/parser\"\n\n")
+	buf.WriteString("// precompiledBanners holds the parsed embedded banners, keyed by their path in bannerFS.\n")
+	buf.WriteString("var precompiledBanners = map[string]parser.Banner{\n")
+	for _, path := range flag.Args() {
+		banner, err := parser.LoadBanner(os.DirFS("."), path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		writeBanner(&buf, path, banner)
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated code: %v", err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// writeBanner writes one map entry holding the glyphs of a banner in code point order.
+func writeBanner(buf *bytes.Buffer, path string, banner parser.Banner) {
+	runes := make([]rune, 0, len(banner))
+	for r := range banner {
+		runes = append(runes, r)
+	}
+	slices.Sort(runes)
+
+	fmt.Fprintf(buf, "%q: {\n", path)
+	for _, r := range runes {
+		fmt.Fprintf(buf, "%q: {", r)
+		for i, row := range banner[r] {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			fmt.Fprintf(buf, "%q", row)
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("},\n")
+}
@@ -5,6 +5,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -64,6 +65,20 @@ func TestMainProgram_Integration(t *testing.T) {
 				return strings.Count(output, "\n") == 16
 			},
 		},
+		{
+			name:        "FIGlet font selected by extension",
+			args:        []string{"Hi", "testdata/mini.flf"},
+			expectError: false,
+			checkOutput: func(output string) bool {
+				return strings.Count(output, "\n") == 8 && strings.Contains(output, "| H || i |")
+			},
+		},
+		{
+			name:        "Missing FIGlet font file",
+			args:        []string{"Hi", "testdata/missing.flf"},
+			expectError: true,
+			checkOutput: nil,
+		},
 		{
 			name:        "No arguments - usage error",
 			args:        []string{},
@@ -218,6 +233,15 @@ func TestRunColorMode(t *testing.T) {
 					strings.Count(output, "\n") == 8
 			},
 		},
+		{
+			name: "text and FIGlet font",
+			args: []string{"--color=red", "Hi", "testdata/mini.flf"},
+			checkOutput: func(output string) bool {
+				return strings.Contains(output, "\033[38;2;255;0;0m") &&
+					strings.Contains(output, "| H |") &&
+					strings.Count(output, "\n") == 8
+			},
+		},
 		{
 			name:        "invalid color name",
 			args:        []string{"--color=notacolor", "hello"},
@@ -292,6 +316,595 @@ func TestMainProgram_RealBannerFiles(t *testing.T) {
 	}
 }
 
+func TestMainProgram_UserBannerDirs(t *testing.T) {
+	dir := t.TempDir()
+	shadow, err := os.ReadFile("testdata/shadow.txt")
+	if err != nil {
+		t.Fatalf("failed to read shadow banner: %v", err)
+	}
+	for _, name := range []string{"mine.txt", "standard.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), shadow, 0o600); err != nil {
+			t.Fatalf("failed to write user banner: %v", err)
+		}
+	}
+
+	expected, err := exec.Command("go", "run", ".", "Hi", "shadow").CombinedOutput()
+	if err != nil {
+		t.Fatalf("failed to render with shadow banner: %v\nOutput: %s", err, expected)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		env  []string
+	}{
+		{"banner-dir flag", []string{"--banner-dir=" + dir, "Hi", "mine"}, nil},
+		{"banner-dir after text", []string{"Hi", "mine", "--banner-dir=" + dir}, nil},
+		{"environment variable", []string{"Hi", "mine"}, []string{"ASCII_ART_BANNER_PATH=" + dir}},
+		{"user file shadows built-in", []string{"Hi", "standard"}, []string{"ASCII_ART_BANNER_PATH=" + dir}},
+		{"direct file path", []string{"Hi", filepath.Join(dir, "mine.txt")}, nil},
+		{"color mode", []string{"--color=red", "--banner-dir=" + dir, "xyz", "Hi", "mine"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command("go", append([]string{"run", "."}, tt.args...)...)
+			cmd.Env = append(os.Environ(), tt.env...)
+			output, err := cmd.CombinedOutput()
+			if err != nil {
+				t.Fatalf("unexpected error: %v\nOutput: %s", err, output)
+			}
+			if string(output) != string(expected) {
+				t.Errorf("expected shadow output:\n%s\ngot:\n%s", expected, output)
+			}
+		})
+	}
+}
+
+func TestMainProgram_BannerFallbackChain(t *testing.T) {
+	shadow, err := os.ReadFile("testdata/shadow.txt")
+	if err != nil {
+		t.Fatalf("failed to read shadow banner: %v", err)
+	}
+	// Keep the glyphs from ' ' up to 'A' only.
+	lines := strings.Split(string(shadow), "\n")
+	partial := filepath.Join(t.TempDir(), "partial.txt")
+	if err := os.WriteFile(partial, []byte(strings.Join(lines[:('A'-' '+1)*9], "\n")), 0o600); err != nil {
+		t.Fatalf("failed to write partial banner: %v", err)
+	}
+
+	shadowA, err := exec.Command("go", "run", ".", "A", "shadow").Output()
+	if err != nil {
+		t.Fatalf("failed to render with shadow banner: %v", err)
+	}
+	standardB, err := exec.Command("go", "run", ".", "B", "standard").Output()
+	if err != nil {
+		t.Fatalf("failed to render with standard banner: %v", err)
+	}
+	aRows := strings.Split(strings.TrimSuffix(string(shadowA), "\n"), "\n")
+	bRows := strings.Split(strings.TrimSuffix(string(standardB), "\n"), "\n")
+	var expected strings.Builder
+	for i := range aRows {
+		expected.WriteString(aRows[i] + bRows[i] + "\n")
+	}
+
+	for _, args := range [][]string{
+		{"--banner=" + partial + ",standard", "AB"},
+		{"AB", partial + ",standard"},
+	} {
+		cmd := exec.Command("go", append([]string{"run", "."}, args...)...)
+		var stderr strings.Builder
+		cmd.Stderr = &stderr
+		output, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v\nStderr: %s", args, err, stderr.String())
+		}
+		if string(output) != expected.String() {
+			t.Errorf("%q: expected shadow 'A' followed by standard 'B':\n%s\ngot:\n%s", args, expected.String(), output)
+		}
+		if !strings.Contains(stderr.String(), "Warning:") || !strings.Contains(stderr.String(), "incomplete banner") {
+			t.Errorf("%q: expected incomplete banner warning, got stderr: %s", args, stderr.String())
+		}
+	}
+
+	cmd := exec.Command("go", "run", ".", "AB", partial)
+	if output, err := cmd.CombinedOutput(); err == nil {
+		t.Errorf("partial banner without fallback should fail, got:\n%s", output)
+	}
+}
+
+func TestMainProgram_Transform(t *testing.T) {
+	plain, err := exec.Command("go", "run", ".", "/A(", "standard").Output()
+	if err != nil {
+		t.Fatalf("failed to render with standard banner: %v", err)
+	}
+	rows := strings.Split(strings.TrimSuffix(string(plain), "\n"), "\n")
+
+	for _, args := range [][]string{
+		{"--transform=scale2x1", "/A("},
+		{"--color=red", "--transform=scale2x1", "/A("},
+	} {
+		cmd := exec.Command("go", append([]string{"run", "."}, args...)...)
+		var stderr strings.Builder
+		cmd.Stderr = &stderr
+		output, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v\nStderr: %s", args, err, stderr.String())
+		}
+		got := strings.Split(strings.TrimSuffix(string(output), "\n"), "\n")
+		if len(got) != len(rows) {
+			t.Fatalf("%q: got %d rows, want %d", args, len(got), len(rows))
+		}
+		if plainWidth := len(rows[1]); len(got[1]) < 2*plainWidth {
+			t.Errorf("%q: row %q is not twice as wide as %q", args, got[1], rows[1])
+		}
+	}
+
+	cmd := exec.Command("go", "run", ".", "--transform=mirror,mirror", "/A(")
+	if output, err := cmd.Output(); err != nil || string(output) != string(plain) {
+		t.Errorf("mirroring twice should restore the text, got:\n%s", output)
+	}
+
+	cmd = exec.Command("go", "run", ".", "--transform=sparkle", "hello")
+	output, err := cmd.CombinedOutput()
+	if err == nil || !strings.Contains(string(output), `unknown transform "sparkle"`) {
+		t.Errorf("unknown transform: err = %v, output:\n%s", err, output)
+	}
+}
+
+func TestMainProgram_Stdin(t *testing.T) {
+	for _, args := range [][]string{
+		{"Hello\\n\\nThere", "shadow"},
+		{"--color=red", "ell", "Hello\\n\\nThere", "shadow"},
+	} {
+		want, err := exec.Command("go", append([]string{"run", "."}, args...)...).Output()
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", args, err)
+		}
+
+		stdinArgs := slices.Clone(args)
+		stdinArgs[len(args)-2] = "-"
+		// Text saved on Windows ends its lines with CRLF.
+		for _, stdin := range []string{"Hello\n\nThere\n", "Hello\r\n\r\nThere\r\n"} {
+			cmd := exec.Command("go", append([]string{"run", "."}, stdinArgs...)...)
+			cmd.Stdin = strings.NewReader(stdin)
+			got, err := cmd.Output()
+			if err != nil {
+				t.Fatalf("%q with stdin %q: unexpected error: %v", stdinArgs, stdin, err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("%q with stdin %q: output differs from argument output:\n%s\nwant:\n%s", stdinArgs, stdin, got, want)
+			}
+		}
+	}
+
+	cmd := exec.Command("go", "run", ".", "-")
+	cmd.Stdin = strings.NewReader("fine\nnot fine: \u00e9\n")
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err == nil || !strings.Contains(stderr.String(), "not found in banner") {
+		t.Errorf("invalid stdin line: err = %v, stderr: %s", err, stderr.String())
+	}
+	if rows := strings.Count(string(output), "\n"); rows != 8 {
+		t.Errorf("expected the first line's 8 rows before the error, got %d:\n%s", rows, output)
+	}
+}
+
+func TestMainProgram_Width(t *testing.T) {
+	const width = 40
+	for _, args := range [][]string{
+		{"--width=40", "hello world foo"},
+		{"--width=40", "--color=red", "lo w", "hello world foo"},
+	} {
+		cmd := exec.Command("go", append([]string{"run", "."}, args...)...)
+		output, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", args, err)
+		}
+		rows := strings.Split(strings.TrimSuffix(string(output), "\n"), "\n")
+		if len(rows) != 3*8 {
+			t.Errorf("%q: got %d rows, want 3 blocks of 8", args, len(rows))
+		}
+		for _, row := range rows {
+			plain := strings.NewReplacer("\033[38;2;255;0;0m", "", "\033[0m", "").Replace(row)
+			if n := len([]rune(plain)); n > width {
+				t.Errorf("%q: row %q is %d columns wide, want at most %d", args, plain, n, width)
+			}
+		}
+	}
+
+	// "lo w" is split by the wrap: "lo" ends the first block and "w" starts the second.
+	output, err := exec.Command("go", "run", ".", "--width=40", "--color=red", "lo w", "hello world foo").Output()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rows := strings.Split(string(output), "\n")
+	if red := "\033[38;2;255;0;0m"; !strings.HasSuffix(rows[2], red+"| |   ___   \033[0m") || !strings.HasPrefix(rows[10], red+"__      __") {
+		t.Errorf("the match is not colored on both sides of the break:\n%q\n%q", rows[2], rows[10])
+	}
+}
+
+func TestMainProgram_Align(t *testing.T) {
+	plain, err := exec.Command("go", "run", ".", "Hi\\nHello").Output()
+	if err != nil {
+		t.Fatalf("failed to render with standard banner: %v", err)
+	}
+	want := strings.Split(strings.TrimSuffix(string(plain), "\n"), "\n")
+
+	stripANSI := strings.NewReplacer("\033[38;2;255;0;0m", "", "\033[0m", "")
+	for _, args := range [][]string{
+		{"--align=right", "--width=60", "Hi\\nHello"},
+		{"--align=right", "--width=60", "--color=red", "H", "Hi\\nHello"},
+	} {
+		output, err := exec.Command("go", append([]string{"run", "."}, args...)...).Output()
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", args, err)
+		}
+		rows := strings.Split(strings.TrimSuffix(string(output), "\n"), "\n")
+		if len(rows) != len(want) {
+			t.Fatalf("%q: got %d rows, want %d", args, len(rows), len(want))
+		}
+		// Each input line is aligned on its own, and ANSI codes are not counted.
+		for i, row := range rows {
+			got := stripANSI.Replace(row)
+			if n := len([]rune(got)); n != 60 {
+				t.Errorf("%q: row %d is %d columns wide, want 60: %q", args, i, n, got)
+			}
+			if strings.TrimLeft(got, " ") != strings.TrimLeft(want[i], " ") {
+				t.Errorf("%q: row %d = %q, want %q right-aligned", args, i, got, want[i])
+			}
+		}
+	}
+
+	output, err := exec.Command("go", "run", ".", "--align=center", "--width=60", "Hi").Output()
+	if err != nil {
+		t.Fatalf("center: unexpected error: %v", err)
+	}
+	rows := strings.Split(string(output), "\n")
+	if indent := (60 - len(want[0])) / 2; rows[0] != strings.Repeat(" ", indent)+want[0] {
+		t.Errorf("center: row %q, want %q indented by %d", rows[0], want[0], indent)
+	}
+
+	// Justified text spreads the extra columns over the spaces.
+	output, err = exec.Command("go", "run", ".", "--align=justify", "--width=60", "a b c").Output()
+	if err != nil {
+		t.Fatalf("justify: unexpected error: %v", err)
+	}
+	for _, row := range strings.Split(strings.TrimSuffix(string(output), "\n"), "\n") {
+		if n := len([]rune(row)); n != 60 {
+			t.Errorf("justify: row %q is %d columns wide, want 60", row, n)
+		}
+	}
+}
+
+func TestMainProgram_Layout(t *testing.T) {
+	full, err := exec.Command("go", "run", ".", "Hello").Output()
+	if err != nil {
+		t.Fatalf("full: unexpected error: %v", err)
+	}
+	smushed, err := exec.Command("go", "run", ".", "--layout=smushing", "Hello").Output()
+	if err != nil {
+		t.Fatalf("smushing: unexpected error: %v", err)
+	}
+	fullRows := strings.Split(string(full), "\n")
+	smushedRows := strings.Split(string(smushed), "\n")
+	if len(smushedRows) != len(fullRows) || len(smushedRows[0]) >= len(fullRows[0]) {
+		t.Errorf("smushed art is not narrower than full width:\n%s", smushed)
+	}
+	if !strings.Contains(string(smushed), `|_|  |_|\___|_|_|\___/`) {
+		t.Errorf("unexpected smushed art:\n%s", smushed)
+	}
+
+	// In color mode the cells are colored by the glyph that drew them, and the
+	// art is the same once the colors are removed.
+	red := "\033[38;2;255;0;0m"
+	colored, err := exec.Command("go", "run", ".", "--layout=smushing", "--color=red", "ll", "Hello").Output()
+	if err != nil {
+		t.Fatalf("color: unexpected error: %v", err)
+	}
+	if plain := strings.NewReplacer(red, "", "\033[0m", "").Replace(string(colored)); plain != string(smushed) {
+		t.Errorf("colored art differs from the smushed art:\n%s", plain)
+	}
+	if !strings.Contains(string(colored), red+"_|_|\033[0m\\___/") {
+		t.Errorf("expected only the l glyphs colored:\n%q", colored)
+	}
+
+	// FIGlet fonts keep their hardblanks for smushing and render them as spaces.
+	font, err := exec.Command("go", "run", ".", "--layout=smushing", `!"`, "testdata/mini.flf").Output()
+	if err != nil {
+		t.Fatalf("FIGlet font: unexpected error: %v", err)
+	}
+	if !strings.Contains(string(font), "+---+---+") || strings.Contains(string(font), "$") {
+		t.Errorf("unexpected smushed FIGlet art:\n%s", font)
+	}
+}
+
+func TestMainProgram_Spacing(t *testing.T) {
+	plain, err := exec.Command("go", "run", ".", "Hi").Output()
+	if err != nil {
+		t.Fatalf("plain: unexpected error: %v", err)
+	}
+	spaced, err := exec.Command("go", "run", ".", "--letter-spacing=2", "--line-spacing=1", `Hi\nHi`).Output()
+	if err != nil {
+		t.Fatalf("spaced: unexpected error: %v", err)
+	}
+	plainRows := strings.Split(strings.TrimSuffix(string(plain), "\n"), "\n")
+	spacedRows := strings.Split(strings.TrimSuffix(string(spaced), "\n"), "\n")
+	if len(spacedRows) != 2*len(plainRows)+1 || spacedRows[len(plainRows)] != "" {
+		t.Fatalf("expected one empty row between the blocks:\n%s", spaced)
+	}
+	if len(spacedRows[0]) != len(plainRows[0])+2 {
+		t.Errorf("row width = %d, want %d", len(spacedRows[0]), len(plainRows[0])+2)
+	}
+
+	// Color mode spaces the art the same way.
+	red := "\033[38;2;255;0;0m"
+	colored, err := exec.Command("go", "run", ".", "--letter-spacing=2", "--line-spacing=1", "--color=red", "i", `Hi\nHi`).Output()
+	if err != nil {
+		t.Fatalf("color: unexpected error: %v", err)
+	}
+	if stripped := strings.NewReplacer(red, "", "\033[0m", "").Replace(string(colored)); stripped != string(spaced) {
+		t.Errorf("colored art differs from the spaced art:\n%s", stripped)
+	}
+}
+
+func TestMainProgram_Vertical(t *testing.T) {
+	out, err := exec.Command("go", "run", ".", "--vertical", "--line-spacing=1", "Hi").Output()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rows := strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+	// Two glyphs of 8 rows with one gap row between them, all as wide as H.
+	if len(rows) != 17 || rows[8] != strings.Repeat(" ", len(rows[0])) {
+		t.Fatalf("unexpected vertical art:\n%s", out)
+	}
+	if rows[9] != "   _     " || rows[0] != " _    _  " {
+		t.Errorf("expected i centered under H:\n%s", out)
+	}
+
+	// Every input line is a column, colored like the plain art.
+	plain, err := exec.Command("go", "run", ".", "--vertical", `Hi\nok`).Output()
+	if err != nil {
+		t.Fatalf("columns: unexpected error: %v", err)
+	}
+	if !strings.Contains(string(plain), "|  __  |        / _ \\") {
+		t.Errorf("expected the columns side by side:\n%s", plain)
+	}
+	red := "\033[38;2;255;0;0m"
+	colored, err := exec.Command("go", "run", ".", "--vertical", "--color=red", "i", `Hi\nok`).Output()
+	if err != nil {
+		t.Fatalf("color: unexpected error: %v", err)
+	}
+	if stripped := strings.NewReplacer(red, "", "\033[0m", "").Replace(string(colored)); stripped != string(plain) {
+		t.Errorf("colored art differs from the plain art:\n%s", stripped)
+	}
+	if !strings.Contains(string(colored), red+"(_)\033[0m") {
+		t.Errorf("expected the i glyph colored:\n%q", colored)
+	}
+}
+
+func TestMainProgram_Reverse(t *testing.T) {
+	dir := t.TempDir()
+	for _, tt := range []struct {
+		args   []string
+		banner string
+	}{
+		{[]string{"Hello, World!\\n\\n42"}, ""},
+		{[]string{"{reverse} me", "thinkertoy"}, "thinkertoy"},
+		{[]string{"--color=red", "me", "{reverse} me", "shadow"}, "shadow"},
+	} {
+		art, err := exec.Command("go", append([]string{"run", "."}, tt.args...)...).Output()
+		if err != nil {
+			t.Fatalf("%q: failed to render: %v", tt.args, err)
+		}
+		path := filepath.Join(dir, "art.txt")
+		if err := os.WriteFile(path, art, 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		args := []string{"run", ".", "--reverse=" + path}
+		if tt.banner != "" {
+			args = append(args, tt.banner)
+		}
+		output, err := exec.Command("go", args...).Output()
+		if err != nil {
+			t.Fatalf("%q: failed to reverse: %v", tt.args, err)
+		}
+		text := tt.args[len(tt.args)-1]
+		if tt.banner != "" {
+			text = tt.args[len(tt.args)-2]
+		}
+		if want := strings.ReplaceAll(text, "\\n", "\n") + "\n"; string(output) != want {
+			t.Errorf("%q: got %q, want %q", tt.args, output, want)
+		}
+	}
+
+	// Art that matches no glyph reports where recognition stopped.
+	path := filepath.Join(dir, "bad.txt")
+	if err := os.WriteFile(path, []byte(strings.Repeat(" _ ?\n", 8)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	output, err := exec.Command("go", "run", ".", "--reverse="+path).CombinedOutput()
+	if err == nil {
+		t.Fatalf("expected an error for unrecognized art, got:\n%s", output)
+	}
+	if !strings.Contains(string(output), "line 1, column") {
+		t.Errorf("expected the position in the error, got %q", output)
+	}
+}
+
+func TestMainProgram_Effects(t *testing.T) {
+	plain, err := exec.Command("go", "run", ".", "Hi").Output()
+	if err != nil {
+		t.Fatalf("failed to render with standard banner: %v", err)
+	}
+	rows := strings.Split(strings.TrimSuffix(string(plain), "\n"), "\n")
+
+	output, err := exec.Command("go", "run", ".", "--effect=shadow:1,1,%", "Hi").Output()
+	if err != nil {
+		t.Fatalf("shadow: unexpected error: %v", err)
+	}
+	got := strings.Split(strings.TrimSuffix(string(output), "\n"), "\n")
+	if len(got) != len(rows)+1 {
+		t.Fatalf("shadow: got %d rows, want %d", len(got), len(rows)+1)
+	}
+	if !strings.Contains(string(output), "%") || strings.Contains(string(plain), "%") {
+		t.Errorf("shadow: expected '%%' shadow cells, got:\n%s", output)
+	}
+	if strings.ReplaceAll(got[0], "%", " ") != rows[0]+" " {
+		t.Errorf("shadow: first row %q does not keep the text row %q", got[0], rows[0])
+	}
+
+	// In color mode the shadow takes its own color, separate from the text.
+	cmd := exec.Command("go", "run", ".", "--color=red", "--effect=shadow", "--effect-color=blue", "Hi")
+	output, err = cmd.Output()
+	if err != nil {
+		t.Fatalf("colored shadow: unexpected error: %v", err)
+	}
+	red, blue := "\033[38;2;255;0;0m", "\033[38;2;0;0;255m"
+	if !strings.Contains(string(output), red+"|") || !strings.Contains(string(output), blue+"░") {
+		t.Errorf("colored shadow: expected red text and blue shadow, got:\n%q", output)
+	}
+
+	cmd = exec.Command("go", "run", ".", "--effect=glow", "hello")
+	output, err = cmd.CombinedOutput()
+	if err == nil || !strings.Contains(string(output), `unknown effect "glow"`) {
+		t.Errorf("unknown effect: err = %v, output:\n%s", err, output)
+	}
+}
+
+func TestMainProgram_ExtendedCharacters(t *testing.T) {
+	standard, err := os.ReadFile("testdata/standard.txt")
+	if err != nil {
+		t.Fatalf("failed to read standard banner: %v", err)
+	}
+	// Tag copies of the Latin 'A' and 'B' glyphs as Greek Alpha and Beta.
+	lines := strings.Split(strings.TrimSuffix(string(standard), "\n"), "\n")
+	glyph := func(r rune) []string {
+		start := int(r-' ') * 9
+		return lines[start+1 : start+9]
+	}
+	extended := append([]string{}, lines...)
+	extended = append(extended, "U+0391..U+0392 Alpha, Beta")
+	extended = append(extended, glyph('A')...)
+	extended = append(extended, "")
+	extended = append(extended, glyph('B')...)
+	greek := filepath.Join(t.TempDir(), "greek.txt")
+	if err := os.WriteFile(greek, []byte(strings.Join(extended, "\n")+"\n"), 0o600); err != nil {
+		t.Fatalf("failed to write banner: %v", err)
+	}
+
+	expected, err := exec.Command("go", "run", ".", "AB A", "standard").CombinedOutput()
+	if err != nil {
+		t.Fatalf("failed to render with standard banner: %v\nOutput: %s", err, expected)
+	}
+	output, err := exec.Command("go", "run", ".", "ΑΒ A", greek).CombinedOutput()
+	if err != nil {
+		t.Fatalf("unexpected error: %v\nOutput: %s", err, output)
+	}
+	if string(output) != string(expected) {
+		t.Errorf("expected Greek glyphs to match their Latin copies:\n%s\ngot:\n%s", expected, output)
+	}
+
+	output, err = exec.Command("go", "run", ".", "--color=red", "Β", "ΑΒ", greek).CombinedOutput()
+	if err != nil || !strings.Contains(string(output), "\033[38;2;255;0;0m") {
+		t.Errorf("color mode with Greek substring failed: %v\nOutput: %s", err, output)
+	}
+
+	output, err = exec.Command("go", "run", ".", "ΑΩ", greek).CombinedOutput()
+	if err == nil || !strings.Contains(string(output), "character Ω (U+03A9) not found in banner") {
+		t.Errorf("expected unsupported character error, got %v\nOutput: %s", err, output)
+	}
+}
+
+func TestMainProgram_BannerInfo(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectError bool
+		checkOutput func(string) bool
+	}{
+		{
+			name: "list banners",
+			args: []string{"--list-banners"},
+			checkOutput: func(output string) bool {
+				return strings.HasPrefix(output, "NAME") &&
+					strings.Contains(output, "thinkertoy") &&
+					strings.Contains(output, "embedded")
+			},
+		},
+		{
+			name: "preview with default text",
+			args: []string{"--preview"},
+			checkOutput: func(output string) bool {
+				return strings.Contains(output, "== standard (Standard, embedded) ==") &&
+					strings.Count(output, "\n") == 3*9+2
+			},
+		},
+		{
+			name: "preview with custom text",
+			args: []string{"--preview=A\\nB"},
+			checkOutput: func(output string) bool {
+				return strings.Count(output, "\n") == 3*17+2
+			},
+		},
+		{
+			name:        "positional arguments rejected",
+			args:        []string{"--list-banners", "Hello"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command("go", append([]string{"run", "."}, tt.args...)...)
+			cmd.Env = append(os.Environ(), "ASCII_ART_BANNER_PATH=", "XDG_DATA_HOME="+t.TempDir())
+			output, err := cmd.CombinedOutput()
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none\nOutput: %s", output)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v\nOutput: %s", err, output)
+			}
+			if !tt.checkOutput(string(output)) {
+				t.Errorf("output check failed\nOutput:\n%s", output)
+			}
+		})
+	}
+}
+
+func TestMainProgram_SubcommandNamesAsText(t *testing.T) {
+	want, err := exec.Command("go", "run", ".", "lint").Output()
+	if err != nil {
+		t.Fatalf("lint: unexpected error: %v", err)
+	}
+	for _, args := range [][]string{{"lint", "standard"}, {"info", "standard"}} {
+		got, err := exec.Command("go", append([]string{"run", "."}, args...)...).CombinedOutput()
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v\n%s", args, err, got)
+		}
+		if args[0] == "lint" && string(got) != string(want) {
+			t.Errorf("%q rendered:\n%s\nwant:\n%s", args, got, want)
+		}
+		if !strings.HasPrefix(string(got), " _") {
+			t.Errorf("%q did not render the text:\n%s", args, got)
+		}
+	}
+
+	// After "--" the banner goes to the subcommand.
+	info, err := exec.Command("go", "run", ".", "info", "--", "shadow").CombinedOutput()
+	if err != nil {
+		t.Fatalf("info -- shadow: unexpected error: %v\n%s", err, info)
+	}
+	if !strings.Contains(string(info), "shadow") {
+		t.Errorf("expected the shadow banner's metadata:\n%s", info)
+	}
+}
+
 func TestMainProgram_ErrorHandling(t *testing.T) {
 	errorTests := []struct {
 		name     string
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"ascii-art-fs/internal/banner"
+	"ascii-art-fs/internal/renderer"
+)
+
+// infoUsageMsg is printed when the banner listing or preview modes get
+// unexpected positional arguments.
+const infoUsageMsg = "Usage: go run . --list-banners | --preview[=<text>]"
+
+// runBannerInfoMode handles execution when --list-banners or --preview is given.
+//
+// Both modes take no positional arguments. When both are given, the listing is
+// printed before the previews.
+//
+// Parameters:
+//   - opts: The extended options.
+//   - args: The remaining command-line arguments including os.Args[0].
+func runBannerInfoMode(opts cliOptions, args []string) {
+	if len(args) > 1 {
+		fmt.Fprintln(os.Stderr, infoUsageMsg)
+		os.Exit(exitCodeUsageError)
+	}
+
+	if opts.listBanners {
+		if err := listBanners(os.Stdout, banners); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitCodeRenderError)
+		}
+	}
+	if opts.preview {
+		if opts.listBanners {
+			fmt.Println()
+		}
+		if err := previewBanners(os.Stdout, banners, opts.previewText); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitCodeRenderError)
+		}
+	}
+}
+
+// listBanners writes a table describing every registered banner.
+//
+// Each banner is loaded to report its height, glyph width range and character
+// coverage. Banners that fail to load are listed with their load error instead
+// of aborting the listing.
+//
+// Parameters:
+//   - w: The destination for the table.
+//   - reg: The banner registry to list.
+//
+// Returns:
+//   - An error if writing to w fails.
+func listBanners(w io.Writer, reg *banner.Registry) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tDISPLAY NAME\tHEIGHT\tWIDTH\tCHARS\tSOURCE\tPATH")
+
+	for _, name := range reg.Names() {
+		info, err := reg.Describe(name)
+		if err != nil {
+			fmt.Fprintf(tw, "%s\t%s\t-\t-\t-\t%s\t%s\n", info.Name, info.DisplayName, info.Source, info.Path)
+			fmt.Fprintf(tw, "  error: %v\n", err)
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d-%d\t%d\t%s\t%s\n",
+			info.Name, info.DisplayName, info.Height, info.MinWidth, info.MaxWidth,
+			info.Coverage, info.Source, info.Path)
+	}
+
+	return tw.Flush()
+}
+
+// previewBanners renders the same text in every registered banner, one after
+// the other, each under a heading with the banner name.
+//
+// Load and render errors are written in place of the art so that one broken
+// banner does not hide the others.
+//
+// Parameters:
+//   - w: The destination for the previews.
+//   - reg: The banner registry to preview.
+//   - text: The sample text to render.
+//
+// Returns:
+//   - An error if writing to w fails.
+func previewBanners(w io.Writer, reg *banner.Registry, text string) error {
+	for i, name := range reg.Names() {
+		info, _ := reg.Lookup(name)
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "== %s (%s, %s) ==\n", info.Name, info.DisplayName, info.Source); err != nil {
+			return err
+		}
+
+		art, err := previewBanner(reg, name, text)
+		if err != nil {
+			art = fmt.Sprintf("error: %v\n", err)
+		}
+		if _, err := io.WriteString(w, art); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// previewBanner loads one banner and renders text with it.
+//
+// Parameters:
+//   - reg: The banner registry.
+//   - name: The banner name.
+//   - text: The text to render.
+//
+// Returns:
+//   - The rendered ASCII art.
+//   - The load or render error.
+func previewBanner(reg *banner.Registry, name, text string) (string, error) {
+	charMap, err := reg.Load(name)
+	if err != nil {
+		return "", err
+	}
+	return renderer.ASCII(text, charMap)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"ascii-art-fs/internal/banner"
+)
+
+func newListingRegistry(t *testing.T) *banner.Registry {
+	t.Helper()
+	reg := newBannerRegistry(nil)
+	err := reg.Register(banner.Spec{
+		Name:   "broken",
+		FS:     os.DirFS("testdata"),
+		Path:   "corrupted.txt",
+		Source: banner.SourceUser,
+	})
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	return reg
+}
+
+func TestListBanners(t *testing.T) {
+	var out bytes.Buffer
+	if err := listBanners(&out, newListingRegistry(t)); err != nil {
+		t.Fatalf("listBanners failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 6 {
+		t.Fatalf("expected header, 4 banners and 1 error line, got %d lines:\n%s", len(lines), out.String())
+	}
+	if fields := strings.Fields(lines[1]); len(fields) != 7 || fields[0] != "standard" ||
+		fields[2] != "8" || fields[4] != "95" || fields[5] != "embedded" {
+		t.Errorf("unexpected standard row: %q", lines[1])
+	}
+	if !strings.HasPrefix(lines[4], "broken") || !strings.Contains(lines[4], "user") {
+		t.Errorf("unexpected broken row: %q", lines[4])
+	}
+	if !strings.Contains(lines[5], "error:") || !strings.Contains(lines[5], "invalid format") {
+		t.Errorf("expected inline load error, got %q", lines[5])
+	}
+}
+
+func TestPreviewBanners(t *testing.T) {
+	var out bytes.Buffer
+	if err := previewBanners(&out, newListingRegistry(t), "Hi"); err != nil {
+		t.Fatalf("previewBanners failed: %v", err)
+	}
+
+	output := out.String()
+	for _, heading := range []string{
+		"== standard (Standard, embedded) ==",
+		"== shadow (Shadow, embedded) ==",
+		"== thinkertoy (Thinkertoy, embedded) ==",
+		"== broken (broken, user) ==",
+	} {
+		if !strings.Contains(output, heading) {
+			t.Errorf("missing heading %q in:\n%s", heading, output)
+		}
+	}
+	if !strings.Contains(output, "error: failed to parse banner") {
+		t.Errorf("expected inline error for broken banner in:\n%s", output)
+	}
+	if strings.Count(output, "\n") != 4+3*8+3+1 {
+		t.Errorf("expected 4 headings, 3 rendered banners, 3 separators and 1 error line, got:\n%s", output)
+	}
+}
@@ -7,13 +7,32 @@
 // Usage:
 //
 //	go run . "text" [banner]
+//	go run . - [banner] < input.txt
 //	go run . --color=<color> "text" [banner]
 //	go run . --color=<color> <substring> "text" [banner]
+//	go run . --banner-dir=<dir> "text" [banner]
+//	go run . --banner=<banner>[,<fallback>...] "text"
+//	go run . --list-banners
+//	go run . --preview[=<text>]
+//	go run . --transform=<name>[,<name>...] "text" [banner]
+//	go run . --effect=<effect> [--effect-color=<color>] "text" [banner]
+//	go run . --width=<columns> "text" [banner]
+//	go run . --align=<left|right|center|justify> [--width=<columns>] "text" [banner]
+//	go run . --layout=<full|kerning|smushing> "text" [banner]
+//	go run . --letter-spacing=<columns> --line-spacing=<rows> "text" [banner]
+//	go run . --vertical [--line-spacing=<rows>] "text" [banner]
+//	go run . --reverse=<file> [banner]
+//	go run . --watch=<banner file> [--watch-interval=<duration>] ["text"]
+//	go run . lint [--fix] <banner.txt>...
+//	go run . convert [--banner-dir=<dir>] [--to=<format>] <banner> <output>
+//	go run . import [--ink=<char>] [--half-blocks] [--to=<format>] <font> <output>
+//	go run . info [--banner-dir=<dir>] <banner>...
+//	go run . diff [--banner-dir=<dir>] [--color=auto|always|never] <old banner> <new banner>
 //
 // Responsibilities of this package:
 //   - Parse and validate command-line arguments
-//   - Route between normal mode and color mode
-//   - Validate and resolve banner file paths
+//   - Route between subcommands, normal mode and color mode
+//   - Validate and resolve banner file paths, including user banner directories
 //   - Coordinate between parser, renderer, and coloring
 //   - Handle errors with appropriate exit codes
 //
@@ -23,17 +42,22 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
-	"ascii-art-fs/internal/parser"
 	"ascii-art-fs/internal/renderer"
 )
 
 const (
 	// Exit codes for different error scenarios.
-	exitCodeUsageError  = 1
-	exitCodeBannerError = 2
-	exitCodeRenderError = 3
-	exitCodeColorError  = 4
+	exitCodeUsageError     = 1
+	exitCodeBannerError    = 2
+	exitCodeRenderError    = 3
+	exitCodeColorError     = 4
+	exitCodeBannerNotFound = 5
+	exitCodeBannerInvalid  = 6
+	// exitCodeDiffFound reports that diff found changes, like diff(1); it
+	// shares its value with usage errors.
+	exitCodeDiffFound = 1
 
 	// Default banner style.
 	defaultBanner = "standard"
@@ -41,38 +65,77 @@ const (
 
 // main is the entry point of the ascii-art application.
 //
-// It determines whether to run in normal mode or color mode based on
-// the presence of the --color flag, then orchestrates the appropriate
-// packages to render ASCII art with optional ANSI color codes.
+// It first extracts the extended options (such as --banner-dir) that may appear
+// anywhere in the arguments, then determines whether to run in normal mode or
+// color mode based on the presence of the --color flag, and orchestrates the
+// appropriate packages to render ASCII art with optional ANSI color codes.
 func main() {
-	if hasColorFlag(os.Args) {
-		runColorMode(os.Args)
+	if cmd, ok := lookupSubcommand(os.Args); ok {
+		os.Exit(cmd(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
+	opts, args, err := extractOptions(os.Args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(exitCodeUsageError)
+	}
+	setBannerDirs(bannerSearchPath(opts.bannerDirs))
+
+	if opts.watch != "" {
+		runWatchMode(opts, args)
+		return
+	}
+
+	if opts.reverse != "" {
+		runReverseMode(opts, args)
+		return
+	}
+
+	if opts.listBanners || opts.preview {
+		runBannerInfoMode(opts, args)
 		return
 	}
 
-	text, banner, err := ParseArgs(os.Args)
+	args, err = appendBannerOption(args, opts.banner)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(exitCodeUsageError)
 	}
 
-	bannerPath, err := GetBannerPath(banner)
+	if hasColorFlag(args) {
+		runColorMode(args, opts)
+		return
+	}
+
+	text, banner, err := ParseArgs(args)
 	if err != nil {
-		fmt.Fprintln(os.Stderr, "Error:", err)
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(exitCodeUsageError)
 	}
 
-	charMap, err := parser.LoadBanner(GetBannerFS(), bannerPath)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading banner file: %v\n", err)
-		os.Exit(exitCodeBannerError)
+	effectCode := effectColorOrExit(opts.effectColor)
+	charMap, layout := loadLayoutBannerOrExit(banner, opts)
+
+	if opts.effect == nil {
+		// Stream the art block by block, so large inputs run in constant memory.
+		if err := renderer.RenderWithOptions(os.Stdout, textReader(text), charMap, layout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error rendering text: %v\n", err)
+			os.Exit(exitCodeRenderError)
+		}
+		return
 	}
 
-	result, err := renderer.ASCII(text, charMap)
-	if err != nil {
+	// Effects need the whole art, since they reach across blocks.
+	var result strings.Builder
+	if err := renderer.RenderWithOptions(&result, textReader(text), charMap, layout); err != nil {
 		fmt.Fprintf(os.Stderr, "Error rendering text: %v\n", err)
 		os.Exit(exitCodeRenderError)
 	}
-
-	fmt.Print(result)
+	if result.Len() == 0 {
+		return
+	}
+	art := strings.Split(strings.TrimSuffix(result.String(), "\n"), "\n")
+	for _, line := range decorate(art, opts.effect, nil, "", effectCode) {
+		fmt.Println(line)
+	}
 }
@@ -1,7 +1,16 @@
 package main
 
 import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+
+	"ascii-art-fs/internal/banner"
+	"ascii-art-fs/internal/renderer"
 )
 
 func TestParseArgs_NoArguments(t *testing.T) {
@@ -255,3 +264,455 @@ func TestGetBannerPath_InvalidBanner(t *testing.T) {
 		t.Error("Expected error for invalid banner, got nil")
 	}
 }
+
+func TestLoadBanner(t *testing.T) {
+	tests := []struct {
+		name        string
+		banner      string
+		wantUnknown bool
+		wantErr     bool
+	}{
+		{"built-in banner", "shadow", false, false},
+		{"FIGlet font path", "testdata/mini.flf", false, false},
+		{"txt file path", "testdata/thinkertoy.txt", false, false},
+		{"broken banner file", "testdata/corrupted.txt", false, true},
+		{"invalid banner", "invalid", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			charMap, err := loadBanner(tt.banner)
+			var unknown *banner.UnknownBannerError
+			if errors.As(err, &unknown) != tt.wantUnknown {
+				t.Errorf("unknown banner error = %v, want %v", err, tt.wantUnknown)
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(charMap) < 95 {
+				t.Errorf("expected at least 95 glyphs, got %d", len(charMap))
+			}
+		})
+	}
+}
+
+func TestLoadBannerChain(t *testing.T) {
+	dir := t.TempDir()
+	// A partial banner with 2-row glyphs up to 'B', where only 'B' has ink.
+	var sb strings.Builder
+	for r := ' '; r <= 'B'; r++ {
+		if r == 'B' {
+			sb.WriteString("\n|B>\n|B>")
+		} else {
+			sb.WriteString("\n\n\n")
+		}
+	}
+	logo := filepath.Join(dir, "logo.txt")
+	if err := os.WriteFile(logo, []byte(sb.String()), 0o644); err != nil {
+		t.Fatalf("failed to write banner: %v", err)
+	}
+
+	charMap, warnings, err := loadBannerChain(logo + ",standard")
+	if err != nil {
+		t.Fatalf("loadBannerChain failed: %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "incomplete banner") {
+		t.Errorf("warnings = %q, want one incomplete banner warning", warnings)
+	}
+	if charMap.Height() != 8 || len(charMap) != 95 {
+		t.Fatalf("got %d glyphs of height %d, want 95 glyphs of height 8", len(charMap), charMap.Height())
+	}
+	if got := charMap['B']; got[0] != "|B>" || got[7] != "   " {
+		t.Errorf("glyph for 'B' = %q, want logo glyph padded to 8 rows", got)
+	}
+
+	if _, _, err := loadBannerChain(logo); err == nil {
+		t.Error("a partial banner on its own should be rejected")
+	}
+	if _, _, err := loadBannerChain("standard,,shadow"); err == nil {
+		t.Error("empty chain entries should be rejected")
+	}
+	var unknown *banner.UnknownBannerError
+	if _, _, err := loadBannerChain("standard,nope"); !errors.As(err, &unknown) {
+		t.Errorf("unknown chain entry error = %v, want *UnknownBannerError", err)
+	}
+}
+
+func TestDescribeBannerError(t *testing.T) {
+	dir := t.TempDir()
+	data, err := os.ReadFile("testdata/standard.txt")
+	if err != nil {
+		t.Fatalf("failed to read standard.txt: %v", err)
+	}
+	messy := filepath.Join(dir, "messy.txt")
+	if err := os.WriteFile(messy, []byte("\uFEFF"+strings.ReplaceAll(string(data), "\n", "\r\n")+"\r\n"), 0o644); err != nil {
+		t.Fatalf("failed to write banner: %v", err)
+	}
+	if _, _, err := loadBannerChain(messy); err != nil {
+		t.Errorf("banner files should be parsed leniently, got %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		spec     string
+		wantCode int
+		wantMsg  string
+	}{
+		{"unknown name", "nope", exitCodeUsageError, "invalid banner name"},
+		{"missing file", filepath.Join(dir, "missing.txt"), exitCodeBannerNotFound, "banner file not found"},
+		{"malformed file", "testdata/corrupted.txt", exitCodeBannerInvalid, "invalid banner file"},
+		{"empty file", "testdata/empty.txt", exitCodeBannerInvalid, "empty banner file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := loadBannerChain(tt.spec)
+			if err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+			msg, code := describeBannerError(err)
+			if code != tt.wantCode || !strings.Contains(msg, tt.wantMsg) {
+				t.Errorf("describeBannerError() = %q, %d; want %q, %d", msg, code, tt.wantMsg, tt.wantCode)
+			}
+		})
+	}
+
+	if msg, code := describeBannerError(errors.New("permission denied")); code != exitCodeBannerError ||
+		!strings.HasPrefix(msg, "Error loading banner file") {
+		t.Errorf("describeBannerError() = %q, %d; want a generic banner error", msg, code)
+	}
+}
+
+func TestAppendBannerOption(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		banner  string
+		want    []string
+		wantErr bool
+	}{
+		{"no option", []string{"prog", "hi"}, "", []string{"prog", "hi"}, false},
+		{"normal mode", []string{"prog", "hi"}, "a,b", []string{"prog", "hi", "a,b"}, false},
+		{"color mode", []string{"prog", "--color=red", "hi"}, "a,b", []string{"prog", "--color=red", "hi", "a,b"}, false},
+		{"missing text", []string{"prog"}, "a,b", nil, true},
+		{"missing color text", []string{"prog", "--color=red"}, "a,b", nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := appendBannerOption(tt.args, tt.banner)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGetBannerPath_InvalidBannerListsRegistry(t *testing.T) {
+	_, err := GetBannerPath("invalid")
+	want := "invalid banner name: \"invalid\"\nValid options: standard, shadow, thinkertoy"
+	if err == nil || err.Error() != want {
+		t.Errorf("GetBannerPath error = %v, want %q", err, want)
+	}
+}
+
+func TestIsValidBanner(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"standard", true},
+		{"fonts/small.flf", true},
+		{"small.FLF", true},
+		{"small.txt", false},
+		{"hello", false},
+		{"standard,shadow", true},
+		{"fonts/small.flf,standard", true},
+		{"standard,hello", false},
+		{"standard,", false},
+	}
+
+	for _, tt := range tests {
+		if got := isValidBanner(tt.name); got != tt.want {
+			t.Errorf("isValidBanner(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestExtractOptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantDirs   []string
+		wantBanner string
+		wantRest   []string
+		wantErr    bool
+	}{
+		{
+			name:     "no options",
+			args:     []string{"prog", "hello", "shadow"},
+			wantRest: []string{"prog", "hello", "shadow"},
+		},
+		{
+			name:     "banner dirs anywhere",
+			args:     []string{"prog", "--banner-dir=a", "hello", "--banner-dir=b", "mine"},
+			wantDirs: []string{"a", "b"},
+			wantRest: []string{"prog", "hello", "mine"},
+		},
+		{
+			name:     "color flag left in place",
+			args:     []string{"prog", "--color=red", "--banner-dir=a", "hello"},
+			wantDirs: []string{"a"},
+			wantRest: []string{"prog", "--color=red", "hello"},
+		},
+		{
+			name:    "empty banner dir",
+			args:    []string{"prog", "--banner-dir=", "hello"},
+			wantErr: true,
+		},
+		{
+			name:       "banner chain",
+			args:       []string{"prog", "--banner=mylogo,standard", "hello"},
+			wantBanner: "mylogo,standard",
+			wantRest:   []string{"prog", "hello"},
+		},
+		{
+			name:    "empty banner",
+			args:    []string{"prog", "hello", "--banner="},
+			wantErr: true,
+		},
+		{
+			name:     "transform",
+			args:     []string{"prog", "hello", "--transform=italic,scale2"},
+			wantRest: []string{"prog", "hello"},
+		},
+		{
+			name:    "unknown transform",
+			args:    []string{"prog", "--transform=shiny", "hello"},
+			wantErr: true,
+		},
+		{
+			name:     "effect with color",
+			args:     []string{"prog", "--effect=shadow:2,1", "hello", "--effect-color=gray"},
+			wantRest: []string{"prog", "hello"},
+		},
+		{
+			name:    "unknown effect",
+			args:    []string{"prog", "--effect=glow", "hello"},
+			wantErr: true,
+		},
+		{
+			name:     "watch",
+			args:     []string{"prog", "--watch=fonts/mine.txt", "--watch-interval=100ms", "Hi"},
+			wantRest: []string{"prog", "Hi"},
+		},
+		{
+			name:    "invalid watch interval",
+			args:    []string{"prog", "--watch=mine.txt", "--watch-interval=soon"},
+			wantErr: true,
+		},
+		{
+			name:     "width",
+			args:     []string{"prog", "--width=80", "hello"},
+			wantRest: []string{"prog", "hello"},
+		},
+		{
+			name:    "zero width",
+			args:    []string{"prog", "--width=0", "hello"},
+			wantErr: true,
+		},
+		{
+			name:     "align",
+			args:     []string{"prog", "--align=center", "hello", "--width=60"},
+			wantRest: []string{"prog", "hello"},
+		},
+		{
+			name:     "reverse",
+			args:     []string{"prog", "--reverse=art.txt", "shadow"},
+			wantRest: []string{"prog", "shadow"},
+		},
+		{
+			name:    "empty reverse",
+			args:    []string{"prog", "--reverse=", "shadow"},
+			wantErr: true,
+		},
+		{
+			name:     "layout",
+			args:     []string{"prog", "--layout=smush", "hello"},
+			wantRest: []string{"prog", "hello"},
+		},
+		{
+			name:    "unknown layout",
+			args:    []string{"prog", "--layout=tight", "hello"},
+			wantErr: true,
+		},
+		{
+			name:     "letter and line spacing",
+			args:     []string{"prog", "--letter-spacing=-1", "hello", "--line-spacing=2"},
+			wantRest: []string{"prog", "hello"},
+		},
+		{
+			name:     "vertical",
+			args:     []string{"prog", "hello", "--vertical", "shadow"},
+			wantRest: []string{"prog", "hello", "shadow"},
+		},
+		{
+			name:    "invalid letter spacing",
+			args:    []string{"prog", "--letter-spacing=wide", "hello"},
+			wantErr: true,
+		},
+		{
+			name:    "negative line spacing",
+			args:    []string{"prog", "--line-spacing=-1", "hello"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown align",
+			args:    []string{"prog", "--align=middle", "hello"},
+			wantErr: true,
+		},
+		{
+			name:    "effect color without effect",
+			args:    []string{"prog", "--effect-color=gray", "hello"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, rest, err := extractOptions(tt.args)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(opts.bannerDirs, tt.wantDirs) {
+				t.Errorf("bannerDirs = %q, want %q", opts.bannerDirs, tt.wantDirs)
+			}
+			if opts.banner != tt.wantBanner {
+				t.Errorf("banner = %q, want %q", opts.banner, tt.wantBanner)
+			}
+			if !reflect.DeepEqual(rest, tt.wantRest) {
+				t.Errorf("rest = %q, want %q", rest, tt.wantRest)
+			}
+			if wantTransform := strings.Contains(strings.Join(tt.args, " "), "--transform="); (opts.transform != nil) != wantTransform {
+				t.Errorf("transform set = %v, want %v", opts.transform != nil, wantTransform)
+			}
+			if wantEffect := strings.Contains(strings.Join(tt.args, " "), "--effect="); (opts.effect != nil) != wantEffect {
+				t.Errorf("effect set = %v, want %v", opts.effect != nil, wantEffect)
+			}
+		})
+	}
+}
+
+func TestRenderOptions(t *testing.T) {
+	t.Setenv("COLUMNS", "100")
+	tests := []struct {
+		name string
+		opts cliOptions
+		want renderer.Options
+	}{
+		{"defaults", cliOptions{}, renderer.Options{}},
+		{"width only", cliOptions{width: 40}, renderer.Options{Width: 40}},
+		{"align with width", cliOptions{width: 40, align: renderer.AlignRight}, renderer.Options{Width: 40, Align: renderer.AlignRight}},
+		{"align uses the terminal width", cliOptions{align: renderer.AlignCenter}, renderer.Options{Width: 100, Align: renderer.AlignCenter}},
+		{"layout", cliOptions{layout: renderer.LayoutKerning}, renderer.Options{Layout: renderer.LayoutKerning}},
+		{"vertical", cliOptions{vertical: true, lineSpacing: 1}, renderer.Options{Vertical: true, LineSpacing: 1}},
+		{"spacing", cliOptions{letterSpacing: -1, lineSpacing: 2}, renderer.Options{LetterSpacing: -1, LineSpacing: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.renderOptions(); got != tt.want {
+				t.Errorf("renderOptions() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBannerSearchPath(t *testing.T) {
+	sep := string(filepath.ListSeparator)
+	t.Setenv("ASCII_ART_BANNER_PATH", "env1"+sep+sep+"env2")
+	t.Setenv("XDG_DATA_HOME", "xdg")
+
+	got := bannerSearchPath([]string{"flag"})
+	want := []string{"flag", "env1", "env2", filepath.Join("xdg", "ascii-art", "banners")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("bannerSearchPath() = %q, want %q", got, want)
+	}
+}
+
+func TestUserBannerDirs(t *testing.T) {
+	high, low := t.TempDir(), t.TempDir()
+	shadow, err := os.ReadFile("testdata/shadow.txt")
+	if err != nil {
+		t.Fatalf("failed to read shadow banner: %v", err)
+	}
+	files := map[string]string{
+		filepath.Join(high, "standard.txt"): "user standard",
+		filepath.Join(low, "standard.txt"):  "lower priority",
+		filepath.Join(low, "mine.flf"):      "user font",
+		filepath.Join(low, "custom.txt"):    string(shadow),
+		filepath.Join(low, ".txt"):          "no name",
+		filepath.Join(low, ".hidden.flf"):   "hidden",
+	}
+	for name, data := range files {
+		if err := os.WriteFile(name, []byte(data), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	setBannerDirs([]string{high, low})
+	defer setBannerDirs(nil)
+
+	tests := []struct {
+		banner   string
+		wantPath string
+		wantData string
+	}{
+		{"standard", "standard.txt", "user standard"},
+		{"mine", "mine.flf", "user font"},
+		{"thinkertoy", "testdata/thinkertoy.txt", ""},
+	}
+	for _, tt := range tests {
+		path, err := GetBannerPath(tt.banner)
+		if err != nil {
+			t.Fatalf("GetBannerPath(%q) failed: %v", tt.banner, err)
+		}
+		if path != tt.wantPath {
+			t.Errorf("GetBannerPath(%q) = %q, want %q", tt.banner, path, tt.wantPath)
+		}
+		info, _ := banners.Lookup(tt.banner)
+		data, err := fs.ReadFile(info.FS, path)
+		if err != nil {
+			t.Fatalf("reading %q from merged view failed: %v", path, err)
+		}
+		if tt.wantData != "" && string(data) != tt.wantData {
+			t.Errorf("merged view %q = %q, want %q", path, data, tt.wantData)
+		}
+	}
+
+	if !isValidBanner("custom") {
+		t.Error("isValidBanner(\"custom\") = false, want true for user banner")
+	}
+	if isValidBanner(".hidden") || isValidBanner("") {
+		t.Error("files without a usable banner name should be skipped")
+	}
+	if isValidBanner("corrupted") {
+		t.Error("isValidBanner(\"corrupted\") = true, want false for test fixture")
+	}
+
+	if _, err := loadBanner(filepath.Join(low, "custom.txt")); err != nil {
+		t.Errorf("loadBanner with direct path failed: %v", err)
+	}
+}
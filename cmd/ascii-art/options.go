@@ -0,0 +1,236 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"ascii-art-fs/internal/effect"
+	"ascii-art-fs/internal/renderer"
+	"ascii-art-fs/internal/transform"
+)
+
+const (
+	bannerDirFlag     = "--banner-dir="
+	bannerFlag        = "--banner="
+	listBannersFlag   = "--list-banners"
+	previewFlag       = "--preview"
+	verticalFlag      = "--vertical"
+	transformFlag     = "--transform="
+	effectFlag        = "--effect="
+	effectColorFlag   = "--effect-color="
+	watchFlag         = "--watch="
+	watchIntervalFlag = "--watch-interval="
+	widthFlag         = "--width="
+	alignFlag         = "--align="
+	reverseFlag       = "--reverse="
+	layoutFlag        = "--layout="
+	letterSpacingFlag = "--letter-spacing="
+	lineSpacingFlag   = "--line-spacing="
+
+	// defaultPreviewText is rendered by --preview when no text is given.
+	defaultPreviewText = "Hello 123"
+)
+
+// cliOptions holds the extended command-line options that may appear anywhere in
+// the argument list, alongside the positional text and banner arguments.
+type cliOptions struct {
+	bannerDirs  []string
+	banner      string
+	listBanners bool
+	preview     bool
+	previewText string
+	// transform derives the banner used for rendering; nil renders the banner as is.
+	transform transform.Transform
+	// effect decorates the rendered art; nil prints the art as is.
+	effect effect.Effect
+	// effectColor is the --effect-color specification for the effect's cells.
+	effectColor string
+	// watch is the banner file re-rendered on every change, or "".
+	watch string
+	// watchInterval is the time between two checks of the watched file.
+	watchInterval time.Duration
+	// width wraps rendered lines to at most this many columns; 0 disables wrapping.
+	width int
+	// align places every rendered block within the width; alignments other
+	// than left use the terminal width when --width is not given.
+	align renderer.Align
+	// layout fits glyphs together at full width, kerned or smushed.
+	layout renderer.Layout
+	// letterSpacing adds columns between glyphs; negative values overlap them.
+	letterSpacing int
+	// lineSpacing adds empty rows between rendered blocks.
+	lineSpacing int
+	// vertical stacks the glyphs top to bottom, one column per input line.
+	vertical bool
+	// reverse is the file of rendered art to turn back into text, or "".
+	reverse string
+}
+
+// extractOptions removes the extended options from args and returns them parsed,
+// together with the remaining arguments.
+//
+// The remaining arguments keep their original order (including args[0]) so they
+// can be handed unchanged to ParseArgs or the color-mode argument handling.
+// Arguments that are not recognized extended options are left in place.
+//
+// Parameters:
+//   - args: Command-line arguments including the program name.
+//
+// Returns:
+//   - The parsed extended options.
+//   - The arguments with extended options removed.
+//   - An error if an extended option has an invalid value.
+func extractOptions(args []string) (cliOptions, []string, error) {
+	opts := cliOptions{watchInterval: defaultWatchInterval}
+	rest := make([]string, 0, len(args))
+
+	for i, arg := range args {
+		if i == 0 {
+			rest = append(rest, arg)
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(arg, bannerDirFlag):
+			dir := strings.TrimPrefix(arg, bannerDirFlag)
+			if dir == "" {
+				return cliOptions{}, nil, fmt.Errorf("empty value for %s", strings.TrimSuffix(bannerDirFlag, "="))
+			}
+			opts.bannerDirs = append(opts.bannerDirs, dir)
+		case strings.HasPrefix(arg, bannerFlag):
+			opts.banner = strings.TrimPrefix(arg, bannerFlag)
+			if opts.banner == "" {
+				return cliOptions{}, nil, fmt.Errorf("empty value for %s", strings.TrimSuffix(bannerFlag, "="))
+			}
+		case strings.HasPrefix(arg, transformFlag):
+			t, err := transform.Parse(strings.TrimPrefix(arg, transformFlag))
+			if err != nil {
+				return cliOptions{}, nil, fmt.Errorf("%s: %w", strings.TrimSuffix(transformFlag, "="), err)
+			}
+			opts.transform = t
+		case strings.HasPrefix(arg, effectFlag):
+			e, err := effect.Parse(strings.TrimPrefix(arg, effectFlag))
+			if err != nil {
+				return cliOptions{}, nil, fmt.Errorf("%s: %w", strings.TrimSuffix(effectFlag, "="), err)
+			}
+			opts.effect = e
+		case strings.HasPrefix(arg, effectColorFlag):
+			opts.effectColor = strings.TrimPrefix(arg, effectColorFlag)
+			if opts.effectColor == "" {
+				return cliOptions{}, nil, fmt.Errorf("empty value for %s", strings.TrimSuffix(effectColorFlag, "="))
+			}
+		case strings.HasPrefix(arg, watchFlag):
+			opts.watch = strings.TrimPrefix(arg, watchFlag)
+			if opts.watch == "" {
+				return cliOptions{}, nil, fmt.Errorf("empty value for %s", strings.TrimSuffix(watchFlag, "="))
+			}
+		case strings.HasPrefix(arg, watchIntervalFlag):
+			interval, err := time.ParseDuration(strings.TrimPrefix(arg, watchIntervalFlag))
+			if err != nil || interval <= 0 {
+				return cliOptions{}, nil, fmt.Errorf("%s: expected a positive duration such as 250ms, got %q",
+					strings.TrimSuffix(watchIntervalFlag, "="), strings.TrimPrefix(arg, watchIntervalFlag))
+			}
+			opts.watchInterval = interval
+		case strings.HasPrefix(arg, widthFlag):
+			width, err := strconv.Atoi(strings.TrimPrefix(arg, widthFlag))
+			if err != nil || width < 1 {
+				return cliOptions{}, nil, fmt.Errorf("%s: expected a positive number of columns, got %q",
+					strings.TrimSuffix(widthFlag, "="), strings.TrimPrefix(arg, widthFlag))
+			}
+			opts.width = width
+		case strings.HasPrefix(arg, alignFlag):
+			align, err := renderer.ParseAlign(strings.TrimPrefix(arg, alignFlag))
+			if err != nil {
+				return cliOptions{}, nil, fmt.Errorf("%s: %w", strings.TrimSuffix(alignFlag, "="), err)
+			}
+			opts.align = align
+		case strings.HasPrefix(arg, layoutFlag):
+			layout, err := renderer.ParseLayout(strings.TrimPrefix(arg, layoutFlag))
+			if err != nil {
+				return cliOptions{}, nil, fmt.Errorf("%s: %w", strings.TrimSuffix(layoutFlag, "="), err)
+			}
+			opts.layout = layout
+		case strings.HasPrefix(arg, letterSpacingFlag):
+			spacing, err := strconv.Atoi(strings.TrimPrefix(arg, letterSpacingFlag))
+			if err != nil {
+				return cliOptions{}, nil, fmt.Errorf("%s: expected a number of columns, got %q",
+					strings.TrimSuffix(letterSpacingFlag, "="), strings.TrimPrefix(arg, letterSpacingFlag))
+			}
+			opts.letterSpacing = spacing
+		case strings.HasPrefix(arg, lineSpacingFlag):
+			spacing, err := strconv.Atoi(strings.TrimPrefix(arg, lineSpacingFlag))
+			if err != nil || spacing < 0 {
+				return cliOptions{}, nil, fmt.Errorf("%s: expected a non-negative number of rows, got %q",
+					strings.TrimSuffix(lineSpacingFlag, "="), strings.TrimPrefix(arg, lineSpacingFlag))
+			}
+			opts.lineSpacing = spacing
+		case strings.HasPrefix(arg, reverseFlag):
+			opts.reverse = strings.TrimPrefix(arg, reverseFlag)
+			if opts.reverse == "" {
+				return cliOptions{}, nil, fmt.Errorf("empty value for %s", strings.TrimSuffix(reverseFlag, "="))
+			}
+		case arg == verticalFlag:
+			opts.vertical = true
+		case arg == listBannersFlag:
+			opts.listBanners = true
+		case arg == previewFlag:
+			opts.preview = true
+			opts.previewText = defaultPreviewText
+		case strings.HasPrefix(arg, previewFlag+"="):
+			opts.preview = true
+			opts.previewText = strings.ReplaceAll(strings.TrimPrefix(arg, previewFlag+"="), "\\n", "\n")
+		default:
+			rest = append(rest, arg)
+		}
+	}
+
+	if opts.effectColor != "" && opts.effect == nil {
+		return cliOptions{}, nil, fmt.Errorf("%s requires %s", strings.TrimSuffix(effectColorFlag, "="), strings.TrimSuffix(effectFlag, "="))
+	}
+
+	return opts, rest, nil
+}
+
+// renderOptions returns the renderer layout options selected on the command line.
+// An alignment other than left without --width aligns to the terminal width.
+func (opts cliOptions) renderOptions() renderer.Options {
+	width := opts.width
+	if width == 0 && opts.align != renderer.AlignLeft {
+		width = terminalWidth()
+	}
+	return renderer.Options{
+		Width:         width,
+		Align:         opts.align,
+		Layout:        opts.layout,
+		LetterSpacing: opts.letterSpacing,
+		LineSpacing:   opts.lineSpacing,
+		Vertical:      opts.vertical,
+	}
+}
+
+// appendBannerOption moves the --banner value to the end of the positional
+// arguments, where normal mode and color mode expect the banner argument.
+//
+// Parameters:
+//   - args: The remaining command-line arguments including os.Args[0].
+//   - bannerSpec: The --banner value, or "" when the option was not given.
+//
+// Returns:
+//   - The arguments with the banner appended.
+//   - An error if no text argument precedes the banner.
+func appendBannerOption(args []string, bannerSpec string) ([]string, error) {
+	if bannerSpec == "" {
+		return args, nil
+	}
+	minArgs := 2
+	if hasColorFlag(args) {
+		minArgs = 3
+	}
+	if len(args) < minArgs {
+		return nil, errors.New(usageMsg)
+	}
+	return append(args, bannerSpec), nil
+}
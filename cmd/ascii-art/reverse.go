@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"ascii-art-fs/internal/reverse"
+)
+
+// reverseUsageMsg is printed when --reverse gets unexpected arguments.
+const reverseUsageMsg = "Usage: go run . --reverse=<file> [banner]"
+
+// runReverseMode handles execution when --reverse is given. It reads rendered
+// art from the file and prints the text it shows.
+//
+// Parameters:
+//   - opts: The extended options; the banner and transform must match the ones
+//     the art was rendered with.
+//   - args: The remaining command-line arguments including os.Args[0]; at most
+//     one banner may follow the program name.
+func runReverseMode(opts cliOptions, args []string) {
+	if len(args) > 2 || (len(args) == 2 && opts.banner != "") {
+		fmt.Fprintln(os.Stderr, reverseUsageMsg)
+		os.Exit(exitCodeUsageError)
+	}
+
+	bannerName := defaultBanner
+	switch {
+	case opts.banner != "":
+		bannerName = opts.banner
+	case len(args) == 2:
+		bannerName = args[1]
+	}
+
+	art, err := readArt(opts.reverse)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading art: %v\n", err)
+		os.Exit(exitCodeRenderError)
+	}
+
+	charMap := loadBannerOrExit(bannerName, opts.transform)
+	text, err := reverse.Recognize(art, charMap)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error recognizing %s: %v\n", opts.reverse, err)
+		os.Exit(exitCodeRenderError)
+	}
+	fmt.Println(text)
+}
+
+// readArt reads the art to recognize from a file, or from standard input
+// when path is "-".
+func readArt(path string) (string, error) {
+	if path == stdinText {
+		data, err := io.ReadAll(os.Stdin)
+		return string(data), err
+	}
+	data, err := os.ReadFile(path)
+	return string(data), err
+}
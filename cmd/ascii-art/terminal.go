@@ -0,0 +1,26 @@
+package main
+
+import (
+	"os"
+	"strconv"
+)
+
+// defaultTerminalWidth is the width --align uses when neither --width nor the
+// terminal gives one.
+const defaultTerminalWidth = 80
+
+// terminalWidth returns the width to align output to when --width is not given:
+// the COLUMNS environment variable, then the width of the terminal on standard
+// output, then defaultTerminalWidth.
+//
+// Returns:
+//   - The width in columns, always positive.
+func terminalWidth() int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	if width := ttyWidth(os.Stdout); width > 0 {
+		return width
+	}
+	return defaultTerminalWidth
+}
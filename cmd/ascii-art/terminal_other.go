@@ -0,0 +1,10 @@
+//go:build !linux && !darwin
+
+package main
+
+import "os"
+
+// ttyWidth returns 0: the terminal width is not detected on this platform.
+func ttyWidth(f *os.File) int {
+	return 0
+}
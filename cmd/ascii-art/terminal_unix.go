@@ -0,0 +1,25 @@
+//go:build linux || darwin
+
+package main
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// winsize mirrors the kernel's struct winsize.
+type winsize struct {
+	rows, cols, xpixel, ypixel uint16
+}
+
+// ttyWidth returns the number of columns of the terminal f is attached to, or
+// 0 if f is not a terminal.
+func ttyWidth(f *os.File) int {
+	var ws winsize
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	if errno != 0 {
+		return 0
+	}
+	return int(ws.cols)
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"ascii-art-fs/internal/parser"
+	"ascii-art-fs/internal/renderer"
+	"ascii-art-fs/internal/transform"
+)
+
+const (
+	// defaultWatchInterval is how often --watch checks the banner file.
+	defaultWatchInterval = 500 * time.Millisecond
+
+	// gridColumns is the number of characters per row of the character grid.
+	gridColumns = 16
+
+	// clearScreen moves the cursor home and clears a terminal.
+	clearScreen = "\033[H\033[2J"
+
+	// watchUsageMsg is printed when --watch gets unexpected arguments.
+	watchUsageMsg = `Usage: go run . --watch=<banner file> [--watch-interval=<duration>] ["text"]`
+)
+
+// watchConfig describes what --watch renders and how.
+type watchConfig struct {
+	// path is the banner file to watch.
+	path string
+	// text is the sample text; "" renders the grid of every defined character.
+	text string
+	// interval is the time between two checks of the file.
+	interval time.Duration
+	// transform is applied to the banner before rendering, or nil.
+	transform transform.Transform
+	// clear clears the screen before every render, for terminals.
+	clear bool
+}
+
+// fileState identifies a version of the watched file.
+type fileState struct {
+	modTime time.Time
+	size    int64
+	err     string
+}
+
+// runWatchMode handles execution when --watch is given. It renders the banner
+// file until the program is interrupted.
+//
+// Parameters:
+//   - opts: The extended options.
+//   - args: The remaining command-line arguments including os.Args[0]; at most
+//     one sample text may follow the program name.
+func runWatchMode(opts cliOptions, args []string) {
+	if len(args) > 2 {
+		fmt.Fprintln(os.Stderr, watchUsageMsg)
+		os.Exit(exitCodeUsageError)
+	}
+
+	cfg := watchConfig{
+		path:      opts.watch,
+		interval:  opts.watchInterval,
+		transform: opts.transform,
+		clear:     isTerminal(os.Stdout),
+	}
+	if len(args) == 2 {
+		cfg.text = strings.ReplaceAll(args[1], "\\n", "\n")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := watchBanner(ctx, os.Stdout, cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitCodeRenderError)
+	}
+}
+
+// watchBanner renders the banner file, then polls it and renders it again
+// every time its modification time or size changes, until ctx is done.
+//
+// A file that cannot be read or parsed does not stop the watch: the error is
+// shown in place of the art, and the next valid version is rendered again.
+//
+// Parameters:
+//   - ctx: Stops the watch when done.
+//   - w: The destination for the renders.
+//   - cfg: The watched file and rendering settings.
+//
+// Returns:
+//   - An error if writing to w fails; nil when ctx is done.
+func watchBanner(ctx context.Context, w io.Writer, cfg watchConfig) error {
+	interval := cfg.interval
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last fileState
+	for first := true; ; first = false {
+		state := statFile(cfg.path)
+		if first || state != last {
+			last = state
+			if err := renderWatched(w, cfg, time.Now()); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// statFile returns the current state of a file, including stat errors so that
+// a file that disappears and comes back counts as a change.
+func statFile(path string) fileState {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileState{err: err.Error()}
+	}
+	return fileState{modTime: info.ModTime(), size: info.Size()}
+}
+
+// renderWatched loads the watched banner file and writes one render: a heading,
+// then the art, or the load or render error in place of the art. Warnings about
+// missing glyphs follow the art, since banners being drawn are often partial.
+//
+// Parameters:
+//   - w: The destination for the render.
+//   - cfg: The watched file and rendering settings.
+//   - now: The time shown in the heading.
+//
+// Returns:
+//   - An error if writing to w fails.
+func renderWatched(w io.Writer, cfg watchConfig, now time.Time) error {
+	var out strings.Builder
+	if cfg.clear {
+		out.WriteString(clearScreen)
+	}
+	fmt.Fprintf(&out, "== %s (%s, Ctrl+C to stop) ==\n", cfg.path, now.Format(time.TimeOnly))
+
+	charMap, warnings, err := parser.LoadPartialWithMode(os.DirFS(filepath.Dir(cfg.path)), filepath.Base(cfg.path), parser.Lenient)
+	if err != nil {
+		msg, _ := describeBannerError(err)
+		fmt.Fprintln(&out, msg)
+	} else {
+		if cfg.transform != nil {
+			charMap = cfg.transform(charMap)
+		}
+		text := cfg.text
+		if text == "" {
+			text = charGrid(charMap)
+		}
+		if art, err := renderer.ASCII(text, charMap); err != nil {
+			fmt.Fprintf(&out, "Error rendering text: %v\n", err)
+		} else {
+			out.WriteString(art)
+		}
+	}
+	for _, warning := range warnings {
+		fmt.Fprintln(&out, "Warning:", warning)
+	}
+
+	_, werr := io.WriteString(w, out.String())
+	return werr
+}
+
+// charGrid lays out every character a banner defines, in code point order,
+// as lines of gridColumns characters.
+//
+// Parameters:
+//   - charMap: The banner.
+//
+// Returns:
+//   - The grid text, with lines separated by '\n'.
+func charGrid(charMap parser.Banner) string {
+	runes := make([]rune, 0, len(charMap))
+	for r := range charMap {
+		runes = append(runes, r)
+	}
+	slices.Sort(runes)
+
+	var rows []string
+	for start := 0; start < len(runes); start += gridColumns {
+		rows = append(rows, string(runes[start:min(start+gridColumns, len(runes))]))
+	}
+	return strings.Join(rows, "\n")
+}
+
+// isTerminal reports whether f is a character device such as a terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"ascii-art-fs/internal/parser"
+)
+
+// syncBuffer is a strings.Builder safe for one writer and one reader.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf strings.Builder
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// waitForCount waits until s occurs at least n times in the output of buf.
+func waitForCount(t *testing.T, buf *syncBuffer, s string, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for strings.Count(buf.String(), s) < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %d × %q, output:\n%s", n, s, buf.String())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestWatchBanner(t *testing.T) {
+	standard, err := os.ReadFile("testdata/standard.txt")
+	if err != nil {
+		t.Fatalf("failed to read standard banner: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "mine.txt")
+	if err := os.WriteFile(path, standard, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var out syncBuffer
+	done := make(chan error, 1)
+	go func() {
+		done <- watchBanner(ctx, &out, watchConfig{path: path, text: "Hi", interval: 5 * time.Millisecond})
+	}()
+
+	// Every render starts with a heading naming the file.
+	heading := "== " + path + " ("
+	waitForCount(t, &out, heading, 1)
+	if !strings.Contains(out.String(), "|  __  | | |") {
+		t.Errorf("first render does not show the text:\n%s", out.String())
+	}
+
+	// A broken file shows the parser error and keeps watching.
+	if err := os.WriteFile(path, append([]byte("x"), standard...), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitForCount(t, &out, "Error: invalid banner file", 1)
+
+	// Fixing the file renders the text again.
+	if err := os.WriteFile(path, standard, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitForCount(t, &out, "|  __  | | |", 2)
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("watchBanner returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("watchBanner did not stop after cancel")
+	}
+	// A poll may also catch a file half written, so only the minimum is exact.
+	if n := strings.Count(out.String(), heading); n < 3 {
+		t.Errorf("got %d renders, want at least 3 (one per version of the file):\n%s", n, out.String())
+	}
+}
+
+func TestRenderWatched(t *testing.T) {
+	dir := t.TempDir()
+	partial := filepath.Join(dir, "partial.txt")
+	standard, err := os.ReadFile("testdata/standard.txt")
+	if err != nil {
+		t.Fatalf("failed to read standard banner: %v", err)
+	}
+	// Keep the glyphs up to 'B' and leave the rest as empty placeholders.
+	lines := strings.Split(strings.TrimSuffix(string(standard), "\n"), "\n")
+	for i := int('C'-' ') * 9; i < len(lines); i++ {
+		lines[i] = ""
+	}
+	if err := os.WriteFile(partial, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		cfg      watchConfig
+		want     []string
+		wantRows int
+	}{
+		{
+			name:     "character grid",
+			cfg:      watchConfig{path: partial},
+			want:     []string{"Warning:"},
+			wantRows: 3 * 8, // 34 characters in rows of 16
+		},
+		{
+			name: "missing character",
+			cfg:  watchConfig{path: partial, text: "BC"},
+			want: []string{"Error rendering text:", "Warning:"},
+		},
+		{
+			name: "missing file",
+			cfg:  watchConfig{path: filepath.Join(dir, "gone.txt"), clear: true},
+			want: []string{clearScreen, "Error: banner file not found"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			if err := renderWatched(&out, tt.cfg, time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)); err != nil {
+				t.Fatalf("renderWatched failed: %v", err)
+			}
+			if !strings.Contains(out.String(), "(15:04:05, Ctrl+C to stop) ==") {
+				t.Errorf("missing heading:\n%s", out.String())
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output does not contain %q:\n%s", want, out.String())
+				}
+			}
+			if tt.wantRows > 0 {
+				rows := strings.Count(out.String(), "\n") - 1 - strings.Count(out.String(), "Warning:")
+				if rows != tt.wantRows {
+					t.Errorf("got %d art rows, want %d:\n%s", rows, tt.wantRows, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestCharGrid(t *testing.T) {
+	charMap := parser.Banner{}
+	for r := 'a'; r <= 'r'; r++ {
+		charMap[r] = []string{string(r)}
+	}
+	if got, want := charGrid(charMap), "abcdefghijklmnop\nqr"; got != want {
+		t.Errorf("charGrid = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,233 @@
+package banner
+
+import (
+	"io/fs"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"ascii-art-fs/internal/parser"
+)
+
+// CacheOptions configures a Cache.
+type CacheOptions struct {
+	// Mode selects how banner files are parsed; the zero value is parser.Strict.
+	Mode parser.Mode
+	// CheckModTime makes every Load stat the file and parse it again when its
+	// modification time or size changed, for banners edited on disk while the
+	// process runs. Files without a modification time, such as embedded ones,
+	// are parsed once.
+	CheckModTime bool
+}
+
+// Cache holds parsed banners keyed by filesystem and path, so that long-running
+// processes parse each banner file once.
+//
+// A Cache is safe for concurrent use. When several goroutines request a banner
+// that is not cached yet, one of them parses the file and the others wait for
+// its result. Failed loads are not cached, so a later Load tries again.
+// Banners are handed out as read-only Views.
+type Cache struct {
+	opts    CacheOptions
+	mu      sync.Mutex
+	entries map[cacheKey]*cacheEntry
+}
+
+// cacheKey identifies a banner file: a filesystem and a path within it.
+type cacheKey struct {
+	fsys any
+	path string
+}
+
+// fsIdentity is the key used for map, slice and function filesystems, such as
+// fstest.MapFS; they are identified by type and address.
+type fsIdentity struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+// cacheEntry is one cached banner, or a load in progress while ready is open.
+type cacheEntry struct {
+	ready   chan struct{}
+	view    View
+	err     error
+	modTime time.Time
+	size    int64
+}
+
+// NewCache returns an empty banner cache.
+//
+// Parameters:
+//   - opts: How banner files are parsed and revalidated.
+//
+// Returns:
+//   - The cache.
+func NewCache(opts CacheOptions) *Cache {
+	return &Cache{opts: opts, entries: make(map[cacheKey]*cacheEntry)}
+}
+
+// Load returns the banner stored at path in fsys, parsing it with
+// parser.LoadWithMode on first use. A filesystem that cannot be told apart
+// from others, such as a struct holding a slice, is parsed on every call.
+//
+// Parameters:
+//   - fsys: The filesystem to read from.
+//   - path: The file path within the filesystem.
+//
+// Returns:
+//   - A read-only view of the parsed banner.
+//   - The read or parse error of the file.
+func (c *Cache) Load(fsys fs.FS, path string) (View, error) {
+	id, ok := identity(fsys)
+	if !ok {
+		b, err := parser.LoadWithMode(fsys, path, c.opts.Mode)
+		return View{banner: b}, err
+	}
+	key := cacheKey{fsys: id, path: path}
+
+	var modTime time.Time
+	var size int64
+	if c.opts.CheckModTime {
+		// A failed stat leaves the zero values; the load reports the error.
+		if info, err := fs.Stat(fsys, path); err == nil {
+			modTime, size = info.ModTime(), info.Size()
+		}
+	}
+
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	if ok && c.opts.CheckModTime && (!e.modTime.Equal(modTime) || e.size != size) {
+		ok = false
+	}
+	if ok {
+		c.mu.Unlock()
+		<-e.ready
+		return e.view, e.err
+	}
+	e = &cacheEntry{ready: make(chan struct{}), modTime: modTime, size: size}
+	c.entries[key] = e
+	c.mu.Unlock()
+
+	b, err := parser.LoadWithMode(fsys, path, c.opts.Mode)
+	e.view, e.err = View{banner: b}, err
+	if err != nil {
+		c.mu.Lock()
+		if c.entries[key] == e {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+	}
+	close(e.ready)
+	return e.view, e.err
+}
+
+// Invalidate removes the banner stored at path in fsys from the cache, so the
+// next Load parses the file again. Views handed out before stay valid.
+//
+// Parameters:
+//   - fsys: The filesystem of the banner.
+//   - path: The file path within the filesystem.
+func (c *Cache) Invalidate(fsys fs.FS, path string) {
+	id, ok := identity(fsys)
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, cacheKey{fsys: id, path: path})
+}
+
+// Len returns the number of cached banners, including loads in progress.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// identity returns a comparable map key for a filesystem: the filesystem
+// itself when it is comparable, or its type and address when it is a map,
+// slice or function. Other filesystems, such as structs holding a slice, have
+// no usable key and are not cached.
+func identity(fsys fs.FS) (any, bool) {
+	v := reflect.ValueOf(fsys)
+	if !v.IsValid() {
+		return fsys, true
+	}
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Func:
+		return fsIdentity{typ: v.Type(), ptr: v.Pointer()}, true
+	}
+	// Comparable checks the values of interface fields too, which would
+	// otherwise make the map lookup panic.
+	if v.Comparable() {
+		return fsys, true
+	}
+	return nil, false
+}
+
+// View is a read-only view of a cached banner. Its methods never expose the
+// shared glyph rows, so callers cannot change the banner seen by others.
+//
+// The zero View is empty.
+type View struct {
+	banner parser.Banner
+}
+
+// Height returns the number of rows in each glyph, or 0 for an empty view.
+func (v View) Height() int {
+	return v.banner.Height()
+}
+
+// Len returns the number of characters the banner defines.
+func (v View) Len() int {
+	return len(v.banner)
+}
+
+// Has reports whether the banner defines a glyph for r.
+func (v View) Has(r rune) bool {
+	_, ok := v.banner[r]
+	return ok
+}
+
+// Glyph returns a copy of the rows of the glyph for r.
+//
+// Parameters:
+//   - r: The character.
+//
+// Returns:
+//   - The glyph rows, which the caller may modify.
+//   - Whether the banner defines r.
+func (v View) Glyph(r rune) ([]string, bool) {
+	rows, ok := v.banner[r]
+	if !ok {
+		return nil, false
+	}
+	return append([]string(nil), rows...), true
+}
+
+// Runes returns the characters the banner defines in ascending order.
+func (v View) Runes() []rune {
+	runes := make([]rune, 0, len(v.banner))
+	for r := range v.banner {
+		runes = append(runes, r)
+	}
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+	return runes
+}
+
+// Banner returns a copy of the banner for packages that take a parser.Banner,
+// such as the renderer.
+//
+// Returns:
+//   - A Banner map with copied glyph rows, which the caller may modify.
+func (v View) Banner() parser.Banner {
+	if v.banner == nil {
+		return nil
+	}
+	b := make(parser.Banner, len(v.banner))
+	for r, rows := range v.banner {
+		b[r] = append([]string(nil), rows...)
+	}
+	return b
+}
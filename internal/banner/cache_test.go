@@ -0,0 +1,204 @@
+package banner_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"ascii-art-fs/internal/banner"
+	"ascii-art-fs/internal/parser"
+)
+
+// countingFS counts how often each file is opened and delays every open, so
+// that concurrent first loads overlap.
+type countingFS struct {
+	fs.FS
+	opens atomic.Int32
+}
+
+func (c *countingFS) Open(name string) (fs.File, error) {
+	c.opens.Add(1)
+	time.Sleep(10 * time.Millisecond)
+	return c.FS.Open(name)
+}
+
+func TestCacheLoadsOnceConcurrently(t *testing.T) {
+	fsys := &countingFS{FS: os.DirFS("../../cmd/ascii-art/testdata")}
+	cache := banner.NewCache(banner.CacheOptions{})
+
+	const goroutines = 32
+	views := make([]banner.View, goroutines)
+	var wg sync.WaitGroup
+	for i := range views {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			view, err := cache.Load(fsys, "standard.txt")
+			if err != nil {
+				t.Errorf("Load failed: %v", err)
+			}
+			views[i] = view
+		}(i)
+	}
+	wg.Wait()
+
+	if got := fsys.opens.Load(); got != 1 {
+		t.Errorf("file opened %d times, want 1", got)
+	}
+	if views[0].Len() != 95 || views[0].Height() != 8 || cache.Len() != 1 {
+		t.Errorf("view has %d glyphs of height %d, cache holds %d banners", views[0].Len(), views[0].Height(), cache.Len())
+	}
+}
+
+func TestCacheViewIsImmutable(t *testing.T) {
+	cache := banner.NewCache(banner.CacheOptions{})
+	testdata := os.DirFS("../../cmd/ascii-art/testdata")
+	view, err := cache.Load(testdata, "standard.txt")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	want, _ := view.Glyph('A')
+
+	rows, ok := view.Glyph('A')
+	if !ok {
+		t.Fatal("Glyph('A') not found")
+	}
+	rows[0] = "changed"
+	copied := view.Banner()
+	copied['A'][1] = "changed"
+	delete(copied, 'B')
+
+	again, _ := cache.Load(testdata, "standard.txt")
+	if got, _ := again.Glyph('A'); !reflect.DeepEqual(got, want) || !again.Has('B') {
+		t.Errorf("cached glyph changed to %q", got)
+	}
+	if runes := again.Runes(); len(runes) != 95 || runes[0] != ' ' || runes[94] != '~' {
+		t.Errorf("Runes() = %q", runes)
+	}
+}
+
+func TestCacheKeysByFilesystemAndPath(t *testing.T) {
+	a := fstest.MapFS{"b.txt": {Data: []byte(buildBanner("a"))}}
+	b := fstest.MapFS{"b.txt": {Data: []byte(buildBanner("b"))}}
+	cache := banner.NewCache(banner.CacheOptions{})
+
+	viewA, errA := cache.Load(a, "b.txt")
+	viewB, errB := cache.Load(b, "b.txt")
+	if errA != nil || errB != nil {
+		t.Fatalf("Load failed: %v, %v", errA, errB)
+	}
+	rowA, _ := viewA.Glyph('A')
+	rowB, _ := viewB.Glyph('A')
+	if rowA[0] != "a" || rowB[0] != "b" || cache.Len() != 2 {
+		t.Errorf("glyphs %q and %q from %d cache entries, want one per filesystem", rowA, rowB, cache.Len())
+	}
+}
+
+// sliceFS is a struct filesystem that cannot be compared, since it holds a slice.
+type sliceFS struct {
+	files []string
+	fsys  fs.FS
+}
+
+func (s sliceFS) Open(name string) (fs.File, error) {
+	return s.fsys.Open(name)
+}
+
+// wrapperFS is a comparable struct filesystem around another filesystem.
+type wrapperFS struct {
+	fs.FS
+}
+
+func TestCacheStructFilesystems(t *testing.T) {
+	files := fstest.MapFS{"b.txt": {Data: []byte(buildBanner("a"))}}
+	cache := banner.NewCache(banner.CacheOptions{})
+
+	// A struct that cannot be a map key is loaded without caching.
+	view, err := cache.Load(sliceFS{files: []string{"b.txt"}, fsys: files}, "b.txt")
+	if err != nil || view.Len() == 0 || cache.Len() != 0 {
+		t.Errorf("Load(sliceFS) = %d glyphs, %v with %d cache entries, want uncached banner", view.Len(), err, cache.Len())
+	}
+	cache.Invalidate(sliceFS{fsys: files}, "b.txt")
+
+	// A struct holding a map in an interface field is not comparable either.
+	if _, err := cache.Load(wrapperFS{files}, "b.txt"); err != nil || cache.Len() != 0 {
+		t.Errorf("Load(wrapperFS{MapFS}) = %v with %d cache entries, want uncached banner", err, cache.Len())
+	}
+
+	// A comparable struct is cached by value.
+	dir := t.TempDir()
+	if err := os.WriteFile(dir+"/b.txt", []byte(buildBanner("a")), 0o644); err != nil {
+		t.Fatalf("failed to write banner: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := cache.Load(wrapperFS{os.DirFS(dir)}, "b.txt"); err != nil {
+			t.Fatalf("Load(wrapperFS{DirFS}) failed: %v", err)
+		}
+	}
+	if cache.Len() != 1 {
+		t.Errorf("cache has %d entries, want 1", cache.Len())
+	}
+}
+
+func TestCacheCheckModTime(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	fsys := fstest.MapFS{"live.txt": {Data: []byte(buildBanner("1")), ModTime: start}}
+
+	for _, check := range []bool{false, true} {
+		cache := banner.NewCache(banner.CacheOptions{CheckModTime: check})
+		fsys["live.txt"] = &fstest.MapFile{Data: []byte(buildBanner("1")), ModTime: start}
+		if _, err := cache.Load(fsys, "live.txt"); err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+
+		fsys["live.txt"] = &fstest.MapFile{Data: []byte(buildBanner("2")), ModTime: start.Add(time.Second)}
+		view, err := cache.Load(fsys, "live.txt")
+		if err != nil {
+			t.Fatalf("Load after change failed: %v", err)
+		}
+		want := "1"
+		if check {
+			want = "2"
+		}
+		if rows, _ := view.Glyph('A'); rows[0] != want {
+			t.Errorf("CheckModTime=%v: glyph row = %q, want %q", check, rows[0], want)
+		}
+
+		cache.Invalidate(fsys, "live.txt")
+		if view, _ := cache.Load(fsys, "live.txt"); view.Len() != 95 {
+			t.Errorf("CheckModTime=%v: reload after Invalidate has %d glyphs", check, view.Len())
+		}
+	}
+}
+
+func TestCacheDoesNotKeepErrors(t *testing.T) {
+	fsys := fstest.MapFS{}
+	cache := banner.NewCache(banner.CacheOptions{Mode: parser.Lenient})
+
+	if _, err := cache.Load(fsys, "late.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("error = %v, want fs.ErrNotExist", err)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("failed load left %d cache entries", cache.Len())
+	}
+
+	fsys["late.txt"] = &fstest.MapFile{Data: []byte(buildBanner("x") + "\r\n\r\n")}
+	if view, err := cache.Load(fsys, "late.txt"); err != nil || view.Len() != 95 {
+		t.Errorf("Load after the file appeared = %d glyphs, %v", view.Len(), err)
+	}
+}
+
+// buildBanner returns a banner file of 1-row glyphs that all read row.
+func buildBanner(row string) string {
+	var data []byte
+	for i := 0; i < 95; i++ {
+		data = append(data, "\n"+row+"\n"...)
+	}
+	return string(data)
+}
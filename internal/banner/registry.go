@@ -0,0 +1,392 @@
+// Package banner provides a registry for discovering, resolving and loading banners.
+//
+// A Registry maps banner names and aliases to banner files in any fs.FS, keeps
+// per-banner metadata such as display name and source, and loads each banner
+// lazily through a Cache the first time it is needed.
+//
+// Responsibilities of this package:
+//   - Register banners from embedded or on-disk filesystems
+//   - Resolve names and aliases, with later registrations shadowing earlier ones
+//   - Enumerate registered banners in registration order
+//   - Load and cache parsed banners and derive their height, widths and coverage
+//   - Cache banner files by filesystem and path for long-running processes (Cache)
+//
+// Lookups of unknown names result in an *UnknownBannerError.
+package banner
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+	"sync"
+
+	"ascii-art-fs/internal/parser"
+)
+
+// Source describes where a registered banner comes from.
+type Source string
+
+const (
+	// SourceEmbedded marks banners compiled into the binary.
+	SourceEmbedded Source = "embedded"
+	// SourceUser marks banners found in a user banner directory.
+	SourceUser Source = "user"
+)
+
+// Spec describes a banner to register.
+//
+// Mode selects how the banner file is parsed; the zero value is parser.Strict.
+// Banner optionally holds the already parsed banner, such as one precompiled
+// from the file at build time; Load then returns a copy of it without reading
+// the file. The registry does not modify it.
+type Spec struct {
+	Name        string
+	DisplayName string
+	Aliases     []string
+	FS          fs.FS
+	Path        string
+	Source      Source
+	Mode        parser.Mode
+	Banner      parser.Banner
+}
+
+// Info is the metadata of a registered banner.
+//
+// Height, MinWidth, MaxWidth and Coverage are only known once the banner has
+// been loaded; they are zero in the results of Lookup and List for banners that
+// were not loaded yet.
+type Info struct {
+	Name        string
+	DisplayName string
+	Aliases     []string
+	FS          fs.FS
+	Path        string
+	Source      Source
+	Mode        parser.Mode
+	Height      int
+	MinWidth    int
+	MaxWidth    int
+	Coverage    int
+	Loaded      bool
+}
+
+// UnknownBannerError is returned when a name matches no registered banner or alias.
+type UnknownBannerError struct {
+	Name  string
+	Valid []string
+}
+
+// Error returns the user-facing message listing the valid banner names.
+func (e *UnknownBannerError) Error() string {
+	return fmt.Sprintf("invalid banner name: %q\nValid options: %s", e.Name, strings.Join(e.Valid, ", "))
+}
+
+// entry is the registry's record of one banner and, once loaded, its view.
+type entry struct {
+	spec   Spec
+	view   View
+	loaded bool
+}
+
+// Registry maps banner names and aliases to banner files.
+//
+// The zero value is not usable; create registries with NewRegistry. A Registry
+// is safe for concurrent use. Banner files are parsed through one Cache per
+// parsing mode, outside the registry's lock, so loading one banner does not
+// block lookups or loads of others.
+type Registry struct {
+	mu      sync.Mutex
+	entries map[string]*entry
+	aliases map[string]string
+	order   []string
+	caches  map[parser.Mode]*Cache
+}
+
+// NewRegistry returns an empty banner registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		entries: make(map[string]*entry),
+		aliases: make(map[string]string),
+		caches:  make(map[parser.Mode]*Cache),
+	}
+}
+
+// Register adds a banner to the registry.
+//
+// Registering a name that is already present replaces the previous banner while
+// keeping its position in List, which lets user banners shadow built-in ones.
+// Aliases listed in the spec are registered as with Alias.
+//
+// Parameters:
+//   - spec: The banner name, location and metadata.
+//
+// Returns:
+//   - An error if the name, filesystem or path is missing, or an alias is invalid.
+func (r *Registry) Register(spec Spec) error {
+	if spec.Name == "" {
+		return errors.New("banner name must not be empty")
+	}
+	if spec.FS == nil || spec.Path == "" {
+		return fmt.Errorf("banner %q: filesystem and path are required", spec.Name)
+	}
+	if spec.DisplayName == "" {
+		spec.DisplayName = spec.Name
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if previous, exists := r.entries[spec.Name]; exists {
+		spec.Aliases = append(append([]string(nil), previous.spec.Aliases...), spec.Aliases...)
+	} else {
+		r.order = append(r.order, spec.Name)
+	}
+	delete(r.aliases, spec.Name)
+	r.entries[spec.Name] = &entry{
+		spec: Spec{
+			Name:        spec.Name,
+			DisplayName: spec.DisplayName,
+			FS:          spec.FS,
+			Path:        spec.Path,
+			Source:      spec.Source,
+			Mode:        spec.Mode,
+		},
+		view:   View{banner: spec.Banner},
+		loaded: spec.Banner != nil,
+	}
+
+	for _, alias := range spec.Aliases {
+		if err := r.addAlias(alias, spec.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Alias makes alias resolve to the registered banner name.
+//
+// Parameters:
+//   - alias: The alternative name.
+//   - name: The registered banner name the alias refers to.
+//
+// Returns:
+//   - An error if the alias is empty, names a registered banner, or name is unknown.
+func (r *Registry) Alias(alias, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.addAlias(alias, name)
+}
+
+// addAlias records an alias; the caller must hold r.mu.
+func (r *Registry) addAlias(alias, name string) error {
+	if alias == "" {
+		return errors.New("banner alias must not be empty")
+	}
+	if _, exists := r.entries[alias]; exists {
+		return fmt.Errorf("alias %q conflicts with a registered banner", alias)
+	}
+	e, exists := r.entries[name]
+	if !exists {
+		return r.unknown(name)
+	}
+	r.aliases[alias] = name
+	if !slices.Contains(e.spec.Aliases, alias) {
+		e.spec.Aliases = append(e.spec.Aliases, alias)
+	}
+	return nil
+}
+
+// Lookup returns the metadata of the banner registered under name or an alias.
+//
+// Lookup never loads the banner, so Height and Coverage are only set if the
+// banner was loaded before.
+//
+// Parameters:
+//   - name: The banner name or alias.
+//
+// Returns:
+//   - The banner metadata.
+//   - true if the name is registered, false otherwise.
+func (r *Registry) Lookup(name string) (Info, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.find(name)
+	if !ok {
+		return Info{}, false
+	}
+	return e.info(), true
+}
+
+// Resolve is like Lookup but reports unknown names with an *UnknownBannerError
+// listing the valid banner names.
+//
+// Parameters:
+//   - name: The banner name or alias.
+//
+// Returns:
+//   - The banner metadata.
+//   - An *UnknownBannerError if the name is not registered.
+func (r *Registry) Resolve(name string) (Info, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.find(name)
+	if !ok {
+		return Info{}, r.unknown(name)
+	}
+	return e.info(), nil
+}
+
+// List returns the metadata of every registered banner in registration order.
+// Banners are not loaded; see Lookup.
+func (r *Registry) List() []Info {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	infos := make([]Info, 0, len(r.order))
+	for _, name := range r.order {
+		infos = append(infos, r.entries[name].info())
+	}
+	return infos
+}
+
+// Names returns the registered banner names in registration order.
+func (r *Registry) Names() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.order...)
+}
+
+// Load returns a copy of the parsed banner registered under name or an alias,
+// which the caller may modify; see LoadView to share the cached banner instead.
+//
+// Parameters:
+//   - name: The banner name or alias.
+//
+// Returns:
+//   - The parsed banner.
+//   - An *UnknownBannerError if the name is not registered, or the parse error.
+func (r *Registry) Load(name string) (parser.Banner, error) {
+	view, err := r.LoadView(name)
+	if err != nil {
+		return nil, err
+	}
+	return view.Banner(), nil
+}
+
+// LoadView returns a read-only view of the parsed banner registered under name
+// or an alias.
+//
+// The banner file is parsed through the registry's Cache on first use and the
+// view is kept for later calls. A failed load is not kept, so a later call
+// tries again. Banners registered with a parsed Banner are viewed as is.
+//
+// Parameters:
+//   - name: The banner name or alias.
+//
+// Returns:
+//   - The view, shared by every caller.
+//   - An *UnknownBannerError if the name is not registered, or the parse error.
+func (r *Registry) LoadView(name string) (View, error) {
+	r.mu.Lock()
+	e, ok := r.find(name)
+	if !ok {
+		defer r.mu.Unlock()
+		return View{}, r.unknown(name)
+	}
+	if e.loaded {
+		defer r.mu.Unlock()
+		return e.view, nil
+	}
+	spec := e.spec
+	cache, ok := r.caches[spec.Mode]
+	if !ok {
+		cache = NewCache(CacheOptions{Mode: spec.Mode})
+		r.caches[spec.Mode] = cache
+	}
+	r.mu.Unlock()
+
+	view, err := cache.Load(spec.FS, spec.Path)
+	if err != nil {
+		return View{}, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// A banner registered under the same name meanwhile keeps its own entry.
+	if r.entries[spec.Name] == e {
+		e.view, e.loaded = view, true
+	}
+	return view, nil
+}
+
+// Describe loads the banner registered under name and returns its complete
+// metadata, including height and character coverage.
+//
+// Parameters:
+//   - name: The banner name or alias.
+//
+// Returns:
+//   - The banner metadata.
+//   - An *UnknownBannerError if the name is not registered, or the parse error.
+func (r *Registry) Describe(name string) (Info, error) {
+	_, err := r.LoadView(name)
+	info, _ := r.Lookup(name)
+	return info, err
+}
+
+// find resolves a name or alias to its entry; the caller must hold r.mu.
+func (r *Registry) find(name string) (*entry, bool) {
+	if target, ok := r.aliases[name]; ok {
+		name = target
+	}
+	e, ok := r.entries[name]
+	return e, ok
+}
+
+// unknown builds the error for an unregistered name; the caller must hold r.mu.
+func (r *Registry) unknown(name string) error {
+	return &UnknownBannerError{Name: name, Valid: append([]string(nil), r.order...)}
+}
+
+// info converts an entry to its public metadata.
+func (e *entry) info() Info {
+	info := Info{
+		Name:        e.spec.Name,
+		DisplayName: e.spec.DisplayName,
+		Aliases:     append([]string(nil), e.spec.Aliases...),
+		FS:          e.spec.FS,
+		Path:        e.spec.Path,
+		Source:      e.spec.Source,
+		Mode:        e.spec.Mode,
+		Loaded:      e.loaded,
+	}
+	if info.Loaded {
+		info.Height = e.view.Height()
+		info.Coverage = e.view.Len()
+		info.MinWidth, info.MaxWidth = widthRange(e.view.banner)
+	}
+	return info
+}
+
+// widthRange returns the narrowest and widest glyph widths of a banner, measured
+// on the first row of each glyph.
+func widthRange(b parser.Banner) (minWidth, maxWidth int) {
+	first := true
+	for _, rows := range b {
+		if len(rows) == 0 {
+			continue
+		}
+		width := len(rows[0])
+		if first || width < minWidth {
+			minWidth = width
+		}
+		if first || width > maxWidth {
+			maxWidth = width
+		}
+		first = false
+	}
+	return minWidth, maxWidth
+}
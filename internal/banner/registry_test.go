@@ -0,0 +1,234 @@
+package banner_test
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"ascii-art-fs/internal/banner"
+	"ascii-art-fs/internal/parser"
+)
+
+func newTestRegistry(t *testing.T) *banner.Registry {
+	t.Helper()
+	testdata := os.DirFS("../../cmd/ascii-art/testdata")
+	reg := banner.NewRegistry()
+	specs := []banner.Spec{
+		{Name: "standard", DisplayName: "Standard", FS: testdata, Path: "standard.txt", Source: banner.SourceEmbedded},
+		{Name: "shadow", FS: testdata, Path: "shadow.txt", Source: banner.SourceEmbedded, Aliases: []string{"dark"}},
+		{Name: "broken", FS: testdata, Path: "corrupted.txt", Source: banner.SourceUser},
+	}
+	for _, spec := range specs {
+		if err := reg.Register(spec); err != nil {
+			t.Fatalf("Register(%q) failed: %v", spec.Name, err)
+		}
+	}
+	return reg
+}
+
+func TestRegistryLookupAndList(t *testing.T) {
+	reg := newTestRegistry(t)
+
+	info, ok := reg.Lookup("standard")
+	if !ok {
+		t.Fatal("Lookup(standard) not found")
+	}
+	if info.DisplayName != "Standard" || info.Source != banner.SourceEmbedded || info.Loaded {
+		t.Errorf("unexpected info before load: %+v", info)
+	}
+
+	if info, ok := reg.Lookup("dark"); !ok || info.Name != "shadow" {
+		t.Errorf("alias lookup = %+v, %v; want shadow", info, ok)
+	}
+	if info, _ := reg.Lookup("shadow"); info.DisplayName != "shadow" {
+		t.Errorf("default DisplayName = %q, want name", info.DisplayName)
+	}
+	if _, ok := reg.Lookup("missing"); ok {
+		t.Error("Lookup(missing) should fail")
+	}
+
+	var names []string
+	for _, info := range reg.List() {
+		names = append(names, info.Name)
+	}
+	want := []string{"standard", "shadow", "broken"}
+	if !reflect.DeepEqual(names, want) || !reflect.DeepEqual(reg.Names(), want) {
+		t.Errorf("List() names = %q, Names() = %q, want %q", names, reg.Names(), want)
+	}
+}
+
+func TestRegistryLoadCachesAndDescribes(t *testing.T) {
+	reg := newTestRegistry(t)
+
+	first, err := reg.Load("dark")
+	if err != nil {
+		t.Fatalf("Load(dark) failed: %v", err)
+	}
+	second, err := reg.Load("shadow")
+	if err != nil {
+		t.Fatalf("Load(shadow) failed: %v", err)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Error("Load should return the same banner under a name and its alias")
+	}
+	// Load hands out copies, so changing one leaves the cached banner intact.
+	want := append([]string(nil), first['A']...)
+	first['A'][0] = "changed"
+	delete(first, 'B')
+	third, err := reg.Load("shadow")
+	if err != nil || !reflect.DeepEqual(third['A'], want) || third['B'] == nil {
+		t.Errorf("Load after changing a returned banner = %q, %v; want the original glyphs", third['A'], err)
+	}
+
+	// The file is parsed once.
+	data, err := os.ReadFile("../../cmd/ascii-art/testdata/standard.txt")
+	if err != nil {
+		t.Fatalf("failed to read standard.txt: %v", err)
+	}
+	fsys := fstest.MapFS{"one.txt": {Data: data}}
+	if err := reg.Register(banner.Spec{Name: "one", FS: fsys, Path: "one.txt"}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if _, err := reg.Load("one"); err != nil {
+		t.Fatalf("Load(one) failed: %v", err)
+	}
+	delete(fsys, "one.txt")
+	if view, err := reg.LoadView("one"); err != nil || view.Len() != 95 {
+		t.Errorf("LoadView(one) after removing the file = %d glyphs, %v; want the cached banner", view.Len(), err)
+	}
+
+	info, err := reg.Describe("standard")
+	if err != nil {
+		t.Fatalf("Describe(standard) failed: %v", err)
+	}
+	if !info.Loaded || info.Height != 8 || info.Coverage != 95 {
+		t.Errorf("Describe(standard) = %+v, want loaded with height 8 and 95 chars", info)
+	}
+	if info.MinWidth <= 0 || info.MinWidth > info.MaxWidth {
+		t.Errorf("Describe(standard) width range = %d-%d, want positive ascending range",
+			info.MinWidth, info.MaxWidth)
+	}
+
+	if _, err := reg.Describe("broken"); err == nil {
+		t.Error("Describe(broken) should return the parse error")
+	}
+}
+
+func TestRegistryUnknownBanner(t *testing.T) {
+	reg := newTestRegistry(t)
+
+	_, err := reg.Load("nope")
+	var unknown *banner.UnknownBannerError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("Load(nope) error = %v, want *UnknownBannerError", err)
+	}
+	want := "invalid banner name: \"nope\"\nValid options: standard, shadow, broken"
+	if err.Error() != want {
+		t.Errorf("error = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestRegistryShadowing(t *testing.T) {
+	reg := newTestRegistry(t)
+	user := fstest.MapFS{"standard.txt": {Data: []byte("not a banner")}}
+
+	if err := reg.Register(banner.Spec{Name: "standard", FS: user, Path: "standard.txt", Source: banner.SourceUser}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	info, _ := reg.Lookup("standard")
+	if info.Source != banner.SourceUser {
+		t.Errorf("Source = %q, want user banner to shadow embedded one", info.Source)
+	}
+	if reg.Names()[0] != "standard" {
+		t.Errorf("shadowing should keep the registration position, got %q", reg.Names())
+	}
+	if _, err := reg.Load("standard"); err == nil || !strings.Contains(err.Error(), "standard.txt") {
+		t.Errorf("Load should parse the shadowing file, got error %v", err)
+	}
+}
+
+func TestRegistryInvalidRegistrations(t *testing.T) {
+	reg := newTestRegistry(t)
+	fsys := fstest.MapFS{}
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"empty name", reg.Register(banner.Spec{FS: fsys, Path: "x.txt"})},
+		{"missing fs", reg.Register(banner.Spec{Name: "x", Path: "x.txt"})},
+		{"missing path", reg.Register(banner.Spec{Name: "x", FS: fsys})},
+		{"empty alias", reg.Alias("", "standard")},
+		{"alias shadows banner", reg.Alias("shadow", "standard")},
+		{"alias to unknown banner", reg.Alias("x", "missing")},
+	}
+	for _, tt := range tests {
+		if tt.err == nil {
+			t.Errorf("%s: expected error, got nil", tt.name)
+		}
+	}
+}
+
+func TestRegistryResolve(t *testing.T) {
+	reg := newTestRegistry(t)
+
+	info, err := reg.Resolve("dark")
+	if err != nil || info.Path != "shadow.txt" {
+		t.Errorf("Resolve(dark) = %+v, %v; want shadow.txt", info, err)
+	}
+
+	var unknown *banner.UnknownBannerError
+	if _, err := reg.Resolve("nope"); !errors.As(err, &unknown) || unknown.Name != "nope" {
+		t.Errorf("Resolve(nope) error = %v, want *UnknownBannerError", err)
+	}
+}
+
+func TestRegistryLoadMode(t *testing.T) {
+	data, err := os.ReadFile("../../cmd/ascii-art/testdata/standard.txt")
+	if err != nil {
+		t.Fatalf("failed to read standard.txt: %v", err)
+	}
+	fsys := fstest.MapFS{"crlf.txt": {Data: []byte(strings.ReplaceAll(string(data), "\n", "\r\n") + "\r\n")}}
+
+	reg := banner.NewRegistry()
+	for _, spec := range []banner.Spec{
+		{Name: "strict", FS: fsys, Path: "crlf.txt"},
+		{Name: "lenient", FS: fsys, Path: "crlf.txt", Mode: parser.Lenient},
+	} {
+		if err := reg.Register(spec); err != nil {
+			t.Fatalf("Register(%q) failed: %v", spec.Name, err)
+		}
+	}
+
+	if _, err := reg.Load("strict"); !errors.Is(err, parser.ErrLineCount) {
+		t.Errorf("strict Load error = %v, want parser.ErrLineCount", err)
+	}
+	if _, err := reg.Load("lenient"); err != nil {
+		t.Errorf("lenient Load failed: %v", err)
+	}
+}
+
+func TestRegistryPreparsedBanner(t *testing.T) {
+	preparsed := parser.Banner{'A': {"A"}}
+	reg := banner.NewRegistry()
+	err := reg.Register(banner.Spec{Name: "pre", FS: fstest.MapFS{}, Path: "missing.txt", Banner: preparsed})
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	got, err := reg.Load("pre")
+	if err != nil || !reflect.DeepEqual(got, preparsed) {
+		t.Errorf("Load() = %q, %v; want the preparsed banner without reading the file", got, err)
+	}
+	got['A'][0] = "changed"
+	if preparsed['A'][0] != "A" {
+		t.Error("changing the loaded banner should not change the registered one")
+	}
+	if info, _ := reg.Lookup("pre"); !info.Loaded || info.Height != 1 {
+		t.Errorf("Lookup() = %+v, want metadata of the preparsed banner", info)
+	}
+}
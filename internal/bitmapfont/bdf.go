@@ -0,0 +1,192 @@
+package bitmapfont
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"ascii-art-fs/internal/parser"
+)
+
+// bdfBox is a bounding box: width, height and the offset of its lower-left
+// corner from the origin on the baseline.
+type bdfBox struct {
+	w, h, x, y int
+}
+
+// bdfChar is the part of a BDF character definition the importer needs.
+type bdfChar struct {
+	encoding int
+	dwidth   int
+	bbx      bdfBox
+	rows     []string
+}
+
+// ReadBDF decodes an X11 BDF font (Glyph Bitmap Distribution Format 2.1) and
+// draws it as a banner.
+//
+// Every character is drawn in a cell as tall as the font (FONT_ASCENT plus
+// FONT_DESCENT, or the font bounding box) and as wide as its advance width
+// (DWIDTH), with its bitmap placed on the common baseline. The ENCODING of a
+// character is used as its code point; characters with encoding -1 are skipped.
+//
+// Parameters:
+//   - r: The font source.
+//   - opts: How to draw the bitmaps.
+//
+// Returns:
+//   - The banner.
+//   - An error naming the line of the first malformed statement.
+func ReadBDF(r io.Reader, opts Options) (parser.Banner, error) {
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	next := func() ([]string, bool) {
+		for scanner.Scan() {
+			lineNo++
+			if fields := strings.Fields(scanner.Text()); len(fields) > 0 {
+				return fields, true
+			}
+		}
+		return nil, false
+	}
+
+	var fontBox bdfBox
+	ascent, descent := -1, -1
+	var chars []bdfChar
+	var cur *bdfChar
+	started := false
+
+	for {
+		fields, ok := next()
+		if !ok {
+			break
+		}
+		var err error
+		switch keyword := fields[0]; keyword {
+		case "STARTFONT":
+			started = true
+		case "FONTBOUNDINGBOX":
+			err = parseInts(fields[1:], &fontBox.w, &fontBox.h, &fontBox.x, &fontBox.y)
+		case "FONT_ASCENT":
+			err = parseInts(fields[1:], &ascent)
+		case "FONT_DESCENT":
+			err = parseInts(fields[1:], &descent)
+		case "STARTCHAR":
+			cur = &bdfChar{encoding: -1, dwidth: -1}
+		case "ENCODING":
+			if cur != nil {
+				err = parseInts(fields[1:2], &cur.encoding)
+			}
+		case "DWIDTH":
+			if cur != nil {
+				var dy int
+				err = parseInts(fields[1:], &cur.dwidth, &dy)
+			}
+		case "BBX":
+			if cur != nil {
+				err = parseInts(fields[1:], &cur.bbx.w, &cur.bbx.h, &cur.bbx.x, &cur.bbx.y)
+			}
+		case "BITMAP":
+			if cur == nil {
+				return nil, fmt.Errorf("line %d: BITMAP outside a character", lineNo)
+			}
+			for i := 0; i < cur.bbx.h; i++ {
+				row, ok := next()
+				if !ok || row[0] == "ENDCHAR" {
+					return nil, fmt.Errorf("line %d: expected %d bitmap rows, got %d", lineNo, cur.bbx.h, i)
+				}
+				cur.rows = append(cur.rows, row[0])
+			}
+		case "ENDCHAR":
+			if cur == nil {
+				return nil, fmt.Errorf("line %d: ENDCHAR outside a character", lineNo)
+			}
+			chars = append(chars, *cur)
+			cur = nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid %s: %w", lineNo, fields[0], err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !started {
+		return nil, fmt.Errorf("not a BDF font: missing STARTFONT")
+	}
+
+	if ascent < 0 || descent < 0 {
+		ascent, descent = fontBox.h+fontBox.y, -fontBox.y
+	}
+	height := ascent + descent
+	if height <= 0 {
+		return nil, fmt.Errorf("invalid font height %d", height)
+	}
+
+	glyphs := make(map[rune]bitmap, len(chars))
+	for _, c := range chars {
+		if c.encoding < 0 {
+			continue
+		}
+		bm, err := c.bitmap(ascent, height)
+		if err != nil {
+			return nil, fmt.Errorf("character %d: %w", c.encoding, err)
+		}
+		glyphs[rune(c.encoding)] = bm
+	}
+	return buildBanner(glyphs, opts)
+}
+
+// bitmap places the character's bitmap in its cell.
+//
+// Parameters:
+//   - ascent: The number of cell rows above the baseline.
+//   - height: The cell height.
+//
+// Returns:
+//   - The cell bitmap; pixels outside the cell are clipped.
+//   - An error if a bitmap row is not valid hexadecimal.
+func (c bdfChar) bitmap(ascent, height int) (bitmap, error) {
+	width := c.dwidth
+	if width < 0 {
+		width = c.bbx.w + c.bbx.x
+	}
+	bm := newBitmap(max(width, 0), height)
+
+	top := ascent - (c.bbx.y + c.bbx.h)
+	for i, row := range c.rows {
+		bits, err := hex.DecodeString(row)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bitmap row %q", row)
+		}
+		y := top + i
+		if y < 0 || y >= height {
+			continue
+		}
+		for col := 0; col < c.bbx.w && col < len(bits)*8; col++ {
+			x := c.bbx.x + col
+			if x >= 0 && x < width && bits[col/8]&(0x80>>(col%8)) != 0 {
+				bm[y][x] = true
+			}
+		}
+	}
+	return bm, nil
+}
+
+// parseInts parses the leading fields into the given integers.
+func parseInts(fields []string, targets ...*int) error {
+	if len(fields) < len(targets) {
+		return fmt.Errorf("expected %d numbers, got %d", len(targets), len(fields))
+	}
+	for i, target := range targets {
+		value, err := strconv.Atoi(fields[i])
+		if err != nil {
+			return err
+		}
+		*target = value
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+// Package bitmapfont imports bitmap fonts as banners.
+//
+// X11 BDF fonts and Linux console PSF fonts (versions 1 and 2, optionally
+// gzip-compressed) are decoded into one bitmap per character, and every bitmap
+// is drawn as a glyph of text rows: set pixels become the ink character and
+// clear pixels spaces. With half-block packing, two pixel rows are drawn as
+// one text row using the characters '▀', '▄' and '█', halving the height.
+//
+// Responsibilities of this package:
+//   - Decode BDF and PSF fonts into character bitmaps
+//   - Map bitmaps to code points
+//   - Draw bitmaps as parser.Banner glyphs
+//
+// The resulting banner can be written in the txt banner format with the
+// convert package.
+package bitmapfont
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"ascii-art-fs/internal/parser"
+)
+
+const (
+	// DefaultInk is the ink character used when Options.Ink is zero.
+	DefaultInk = '#'
+
+	gzipExtension = ".gz"
+	bdfExtension  = ".bdf"
+	psfExtension  = ".psf"
+	psfuExtension = ".psfu"
+)
+
+// Options controls how bitmaps are drawn as glyphs.
+type Options struct {
+	// Ink is the character drawn for set pixels; zero means DefaultInk.
+	Ink rune
+	// HalfBlocks packs two pixel rows into one text row with '▀', '▄' and '█',
+	// ignoring Ink.
+	HalfBlocks bool
+}
+
+// bitmap is the pixel grid of one character, with every row as wide as the cell.
+type bitmap [][]bool
+
+// IsFontPath reports whether name has a bitmap font extension (".bdf", ".psf"
+// or ".psfu", optionally followed by ".gz").
+func IsFontPath(name string) bool {
+	switch fontExtension(name) {
+	case bdfExtension, psfExtension, psfuExtension:
+		return true
+	}
+	return false
+}
+
+// fontExtension returns the lower-case extension of name, ignoring a trailing ".gz".
+func fontExtension(name string) string {
+	name = strings.ToLower(name)
+	return path.Ext(strings.TrimSuffix(name, gzipExtension))
+}
+
+// Load reads a bitmap font and draws it as a banner, choosing the decoder
+// from the file extension. Files ending in ".gz" are decompressed first.
+//
+// Parameters:
+//   - fsys: The filesystem to read from.
+//   - name: The file path within the filesystem.
+//   - opts: How to draw the bitmaps.
+//
+// Returns:
+//   - The banner holding every printable character the font maps.
+//   - An error if the file cannot be read or decoded.
+func Load(fsys fs.FS, name string, opts Options) (parser.Banner, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read font %q: %w", name, err)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.EqualFold(path.Ext(name), gzipExtension) {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read font %q: %w", name, err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	var banner parser.Banner
+	switch fontExtension(name) {
+	case bdfExtension:
+		banner, err = ReadBDF(r, opts)
+	case psfExtension, psfuExtension:
+		banner, err = ReadPSF(r, opts)
+	default:
+		return nil, fmt.Errorf("unsupported font %q: expected a .bdf, .psf or .psfu file", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to import font %q: %w", name, err)
+	}
+	return banner, nil
+}
+
+// buildBanner draws the bitmaps of a font as banner glyphs, skipping code
+// points that are not printable.
+//
+// Parameters:
+//   - glyphs: The bitmaps by code point.
+//   - opts: How to draw the bitmaps.
+//
+// Returns:
+//   - The banner.
+//   - An error if no printable character is mapped.
+func buildBanner(glyphs map[rune]bitmap, opts Options) (parser.Banner, error) {
+	banner := make(parser.Banner, len(glyphs))
+	for r, bm := range glyphs {
+		if !utf8.ValidRune(r) || unicode.IsControl(r) {
+			continue
+		}
+		banner[r] = draw(bm, opts)
+	}
+	if len(banner) == 0 {
+		return nil, fmt.Errorf("font maps no printable characters")
+	}
+	return banner, nil
+}
+
+// draw renders a bitmap as glyph rows.
+//
+// Parameters:
+//   - bm: The bitmap.
+//   - opts: How to draw it.
+//
+// Returns:
+//   - The glyph rows.
+func draw(bm bitmap, opts Options) []string {
+	if opts.HalfBlocks {
+		return drawHalfBlocks(bm)
+	}
+	ink := opts.Ink
+	if ink == 0 {
+		ink = DefaultInk
+	}
+
+	rows := make([]string, len(bm))
+	for y, pixels := range bm {
+		var sb strings.Builder
+		for _, set := range pixels {
+			if set {
+				sb.WriteRune(ink)
+			} else {
+				sb.WriteByte(' ')
+			}
+		}
+		rows[y] = sb.String()
+	}
+	return rows
+}
+
+// drawHalfBlocks renders a bitmap with two pixel rows per text row; a missing
+// last row of an odd-height bitmap counts as clear.
+//
+// Parameters:
+//   - bm: The bitmap.
+//
+// Returns:
+//   - The glyph rows.
+func drawHalfBlocks(bm bitmap) []string {
+	rows := make([]string, 0, (len(bm)+1)/2)
+	for y := 0; y < len(bm); y += 2 {
+		var sb strings.Builder
+		for x, top := range bm[y] {
+			bottom := y+1 < len(bm) && bm[y+1][x]
+			switch {
+			case top && bottom:
+				sb.WriteRune('█')
+			case top:
+				sb.WriteRune('▀')
+			case bottom:
+				sb.WriteRune('▄')
+			default:
+				sb.WriteByte(' ')
+			}
+		}
+		rows = append(rows, sb.String())
+	}
+	return rows
+}
+
+// newBitmap returns a clear bitmap of the given size.
+func newBitmap(width, height int) bitmap {
+	bm := make(bitmap, height)
+	for y := range bm {
+		bm[y] = make([]bool, width)
+	}
+	return bm
+}
@@ -0,0 +1,229 @@
+package bitmapfont
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// testBDF is a 4x4 font (ascent 3, descent 1) with 'A', a descender 'g' and
+// an unencoded character.
+const testBDF = `STARTFONT 2.1
+FONT -test-fixed-medium-r-normal--4-40-75-75-c-40-iso10646-1
+SIZE 4 75 75
+FONTBOUNDINGBOX 4 4 0 -1
+STARTPROPERTIES 2
+FONT_ASCENT 3
+FONT_DESCENT 1
+ENDPROPERTIES
+CHARS 3
+STARTCHAR A
+ENCODING 65
+SWIDTH 1000 0
+DWIDTH 4 0
+BBX 3 3 0 0
+BITMAP
+40
+A0
+E0
+ENDCHAR
+STARTCHAR g
+ENCODING 103
+DWIDTH 4 0
+BBX 2 2 1 -1
+BITMAP
+C0
+40
+ENDCHAR
+STARTCHAR unencoded
+ENCODING -1
+DWIDTH 4 0
+BBX 1 1 0 0
+BITMAP
+80
+ENDCHAR
+ENDFONT
+`
+
+// psfGlyph is a 4-row, 8-pixel-wide test glyph.
+var psfGlyph = []byte{0x80, 0x40, 0x20, 0x10}
+
+// buildPSF1 returns a 256-glyph PSF1 font where glyph 65 is psfGlyph and the
+// Unicode table, if any, maps glyph 1 to 'Ω' and 'Ὡ' and glyph 65 to 'A'.
+func buildPSF1(withTable bool) []byte {
+	mode := byte(0)
+	if withTable {
+		mode = psf1ModeHasTab
+	}
+	data := []byte{0x36, 0x04, mode, byte(len(psfGlyph))}
+	for i := 0; i < 256; i++ {
+		if i == 65 || i == 1 {
+			data = append(data, psfGlyph...)
+		} else {
+			data = append(data, make([]byte, len(psfGlyph))...)
+		}
+	}
+	if withTable {
+		for i := 0; i < 256; i++ {
+			switch i {
+			case 1:
+				data = binary.LittleEndian.AppendUint16(data, 'Ω')
+				data = binary.LittleEndian.AppendUint16(data, 'Ὡ')
+			case 65:
+				data = binary.LittleEndian.AppendUint16(data, 'A')
+				data = binary.LittleEndian.AppendUint16(data, psf1StartSeq)
+				data = binary.LittleEndian.AppendUint16(data, 'B')
+			}
+			data = binary.LittleEndian.AppendUint16(data, psf1Separator)
+		}
+	}
+	return data
+}
+
+// buildPSF2 returns a two-glyph 3x2 PSF2 font mapping glyph 0 to 'é' and
+// glyph 1 to 'x'.
+func buildPSF2() []byte {
+	var buf bytes.Buffer
+	buf.Write(psf2Magic)
+	binary.Write(&buf, binary.LittleEndian, psf2Header{
+		HeaderSize: 32, Flags: psf2HasUnicodeTable, Length: 2, CharSize: 2, Height: 2, Width: 3,
+	})
+	buf.Write([]byte{0xA0, 0x40, 0x40, 0xA0})
+	buf.WriteString("é\xFFx\xFF")
+	return buf.Bytes()
+}
+
+func TestReadBDF(t *testing.T) {
+	banner, err := ReadBDF(strings.NewReader(testBDF), Options{})
+	if err != nil {
+		t.Fatalf("ReadBDF failed: %v", err)
+	}
+	want := map[rune][]string{
+		'A': {" #  ", "# # ", "### ", "    "},
+		'g': {"    ", "    ", " ## ", "  # "},
+	}
+	if !reflect.DeepEqual(map[rune][]string(banner), want) {
+		t.Errorf("banner = %q, want %q", banner, want)
+	}
+}
+
+func TestReadBDFErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"not bdf", "hello\n", "missing STARTFONT"},
+		{"bad number", strings.Replace(testBDF, "BBX 3 3 0 0", "BBX 3 x 0 0", 1), "line 14: invalid BBX"},
+		{"short bitmap", strings.Replace(testBDF, "E0\n", "", 1), "expected 3 bitmap rows"},
+		{"bad hex", strings.Replace(testBDF, "A0", "Z0", 1), `character 65: invalid bitmap row "Z0"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadBDF(strings.NewReader(tt.data), Options{})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadPSF(t *testing.T) {
+	diagonal := []string{"#       ", " #      ", "  #     ", "   #    "}
+
+	tests := []struct {
+		name string
+		data []byte
+		want map[rune][]string
+	}{
+		{"psf1 with table", buildPSF1(true), map[rune][]string{'Ω': diagonal, 'Ὡ': diagonal, 'A': diagonal}},
+		{"psf2", buildPSF2(), map[rune][]string{'é': {"# #", " # "}, 'x': {" # ", "# #"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			banner, err := ReadPSF(bytes.NewReader(tt.data), Options{})
+			if err != nil {
+				t.Fatalf("ReadPSF failed: %v", err)
+			}
+			if !reflect.DeepEqual(map[rune][]string(banner), tt.want) {
+				t.Errorf("banner = %q, want %q", banner, tt.want)
+			}
+		})
+	}
+
+	banner, err := ReadPSF(bytes.NewReader(buildPSF1(false)), Options{})
+	if err != nil {
+		t.Fatalf("ReadPSF without table failed: %v", err)
+	}
+	if len(banner) != 95 || !reflect.DeepEqual(banner['A'], diagonal) {
+		t.Errorf("font without table: %d glyphs, 'A' = %q", len(banner), banner['A'])
+	}
+
+	if _, err := ReadPSF(bytes.NewReader(buildPSF1(true)[:100]), Options{}); err == nil {
+		t.Error("ReadPSF should reject truncated glyph data")
+	}
+	if _, err := ReadPSF(strings.NewReader("STARTFONT"), Options{}); err == nil {
+		t.Error("ReadPSF should reject a file without PSF magic")
+	}
+}
+
+func TestDrawOptions(t *testing.T) {
+	bm := bitmap{
+		{true, false, true},
+		{true, true, false},
+		{false, true, false},
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+		want []string
+	}{
+		{"default ink", Options{}, []string{"# #", "## ", " # "}},
+		{"custom ink", Options{Ink: '█'}, []string{"█ █", "██ ", " █ "}},
+		{"half blocks", Options{HalfBlocks: true, Ink: '@'}, []string{"█▄▀", " ▀ "}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := draw(bm, tt.opts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("draw = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	w.Write(buildPSF2())
+	w.Close()
+
+	fsys := fstest.MapFS{
+		"fonts/test.bdf":     {Data: []byte(testBDF)},
+		"fonts/test.psfu.gz": {Data: gz.Bytes()},
+		"fonts/test.ttf":     {Data: []byte("ttf")},
+	}
+
+	if banner, err := Load(fsys, "fonts/test.bdf", Options{}); err != nil || len(banner) != 2 {
+		t.Errorf("Load(test.bdf) = %d glyphs, %v", len(banner), err)
+	}
+	if banner, err := Load(fsys, "fonts/test.psfu.gz", Options{}); err != nil || len(banner) != 2 {
+		t.Errorf("Load(test.psfu.gz) = %d glyphs, %v", len(banner), err)
+	}
+	if _, err := Load(fsys, "fonts/test.ttf", Options{}); err == nil || !strings.Contains(err.Error(), "unsupported font") {
+		t.Errorf("Load(test.ttf) error = %v", err)
+	}
+	if _, err := Load(fsys, "fonts/missing.bdf", Options{}); err == nil {
+		t.Error("Load should fail for a missing file")
+	}
+
+	for name, want := range map[string]bool{"a.bdf": true, "A.PSF.GZ": true, "a.psfu": true, "a.txt": false, "a.gz": false} {
+		if got := IsFontPath(name); got != want {
+			t.Errorf("IsFontPath(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package bitmapfont
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"ascii-art-fs/internal/parser"
+)
+
+const (
+	psf1Mode512    = 0x01
+	psf1ModeHasTab = 0x02
+	psf1Separator  = 0xFFFF
+	psf1StartSeq   = 0xFFFE
+
+	psf2HasUnicodeTable = 0x01
+	psf2Separator       = 0xFF
+	psf2StartSeq        = 0xFE
+)
+
+var (
+	psf1Magic = []byte{0x36, 0x04}
+	psf2Magic = []byte{0x72, 0xb5, 0x4a, 0x86}
+)
+
+// psf2Header is the fixed part of a PSF2 header after the magic bytes.
+type psf2Header struct {
+	Version    uint32
+	HeaderSize uint32
+	Flags      uint32
+	Length     uint32
+	CharSize   uint32
+	Height     uint32
+	Width      uint32
+}
+
+// ReadPSF decodes a Linux console font in PSF1 or PSF2 format and draws it as
+// a banner.
+//
+// Code points are taken from the font's Unicode table; a glyph listed for
+// several code points is used for each of them, and multi-code-point sequences
+// are ignored. Fonts without a table are assumed to follow ASCII, so only their
+// glyphs 32 to 126 are imported.
+//
+// Parameters:
+//   - r: The font source.
+//   - opts: How to draw the bitmaps.
+//
+// Returns:
+//   - The banner.
+//   - An error if the data is not a valid PSF font.
+func ReadPSF(r io.Reader, opts Options) (parser.Banner, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var glyphs map[rune]bitmap
+	switch {
+	case bytes.HasPrefix(data, psf1Magic):
+		glyphs, err = readPSF1(data)
+	case bytes.HasPrefix(data, psf2Magic):
+		glyphs, err = readPSF2(data)
+	default:
+		return nil, errors.New("not a PSF font: bad magic number")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buildBanner(glyphs, opts)
+}
+
+// readPSF1 decodes a PSF1 font: 8-pixel-wide glyphs with a 16-bit Unicode table.
+func readPSF1(data []byte) (map[rune]bitmap, error) {
+	if len(data) < 4 {
+		return nil, errors.New("truncated PSF1 header")
+	}
+	mode, charSize := data[2], int(data[3])
+	count := 256
+	if mode&psf1Mode512 != 0 {
+		count = 512
+	}
+	bitmaps, rest, err := psfBitmaps(data[4:], count, charSize, 8, charSize)
+	if err != nil {
+		return nil, err
+	}
+	if mode&psf1ModeHasTab == 0 {
+		return asciiGlyphs(bitmaps), nil
+	}
+
+	glyphs := make(map[rune]bitmap)
+	index, inSequence := 0, false
+	for ; len(rest) >= 2 && index < count; rest = rest[2:] {
+		switch value := binary.LittleEndian.Uint16(rest); value {
+		case psf1Separator:
+			index++
+			inSequence = false
+		case psf1StartSeq:
+			inSequence = true
+		default:
+			if !inSequence {
+				glyphs[rune(value)] = bitmaps[index]
+			}
+		}
+	}
+	return glyphs, nil
+}
+
+// readPSF2 decodes a PSF2 font: glyphs of any size with a UTF-8 Unicode table.
+func readPSF2(data []byte) (map[rune]bitmap, error) {
+	var h psf2Header
+	if err := binary.Read(bytes.NewReader(data[len(psf2Magic):]), binary.LittleEndian, &h); err != nil {
+		return nil, errors.New("truncated PSF2 header")
+	}
+	rowBytes := (h.Width + 7) / 8
+	if h.Width == 0 || h.Height == 0 || h.CharSize != rowBytes*h.Height {
+		return nil, fmt.Errorf("invalid PSF2 glyph size %dx%d (%d bytes)", h.Width, h.Height, h.CharSize)
+	}
+	if uint64(h.HeaderSize) > uint64(len(data)) {
+		return nil, errors.New("truncated PSF2 header")
+	}
+	bitmaps, rest, err := psfBitmaps(data[h.HeaderSize:], int(h.Length), int(h.CharSize), int(h.Width), int(h.Height))
+	if err != nil {
+		return nil, err
+	}
+	if h.Flags&psf2HasUnicodeTable == 0 {
+		return asciiGlyphs(bitmaps), nil
+	}
+
+	glyphs := make(map[rune]bitmap)
+	index, inSequence := 0, false
+	for len(rest) > 0 && index < len(bitmaps) {
+		switch rest[0] {
+		case psf2Separator:
+			index++
+			inSequence = false
+			rest = rest[1:]
+			continue
+		case psf2StartSeq:
+			inSequence = true
+			rest = rest[1:]
+			continue
+		}
+		r, size := utf8.DecodeRune(rest)
+		if r == utf8.RuneError && size <= 1 {
+			return nil, fmt.Errorf("invalid UTF-8 in the Unicode table of glyph %d", index)
+		}
+		if !inSequence {
+			glyphs[r] = bitmaps[index]
+		}
+		rest = rest[size:]
+	}
+	return glyphs, nil
+}
+
+// psfBitmaps decodes the glyph bitmaps of a PSF font.
+//
+// Parameters:
+//   - data: The font data starting at the first glyph.
+//   - count: The number of glyphs.
+//   - charSize: The number of bytes per glyph.
+//   - width: The glyph width in pixels; rows are padded to whole bytes.
+//   - height: The glyph height in pixels.
+//
+// Returns:
+//   - The bitmaps in font order.
+//   - The data following the glyphs (the Unicode table, if any).
+//   - An error if the data holds fewer glyphs than declared.
+func psfBitmaps(data []byte, count, charSize, width, height int) ([]bitmap, []byte, error) {
+	if count <= 0 || charSize <= 0 || count*charSize > len(data) {
+		return nil, nil, fmt.Errorf("truncated glyph data: want %d glyphs of %d bytes", count, charSize)
+	}
+	rowBytes := (width + 7) / 8
+	bitmaps := make([]bitmap, count)
+	for i := range bitmaps {
+		glyph := data[i*charSize : (i+1)*charSize]
+		bm := newBitmap(width, height)
+		for y := range bm {
+			row := glyph[y*rowBytes : (y+1)*rowBytes]
+			for x := range bm[y] {
+				bm[y][x] = row[x/8]&(0x80>>(x%8)) != 0
+			}
+		}
+		bitmaps[i] = bm
+	}
+	return bitmaps, data[count*charSize:], nil
+}
+
+// asciiGlyphs maps the printable ASCII positions of a font without a Unicode
+// table to their code points.
+func asciiGlyphs(bitmaps []bitmap) map[rune]bitmap {
+	glyphs := make(map[rune]bitmap)
+	for r := rune(' '); r <= '~' && int(r) < len(bitmaps); r++ {
+		glyphs[r] = bitmaps[r]
+	}
+	return glyphs
+}
@@ -7,6 +7,7 @@ package coloring
 
 import (
 	"strings"
+	"unicode/utf8"
 )
 
 // Reset is the ANSI escape sequence used to reset terminal coloring back
@@ -39,7 +40,46 @@ func ApplyColor(
 		return asciiArt
 	}
 
-	positions := findPositions(text, substring)
+	return ApplyPositions(asciiArt, findPositions(text, substring), colorCode, charWidths)
+}
+
+// Positions returns which characters of text ApplyColor colors for substring.
+//
+// The result is indexed like charWidths, so callers that render a text in
+// parts, such as wrapped lines, can find the matches in the whole text and
+// slice the positions together with the text.
+//
+// Parameters:
+//   - text: The text to search for substring matches.
+//   - substring: The substring to find; if empty, all positions are marked true.
+//
+// Returns:
+//   - A boolean slice with the same length as text, with true for matched positions.
+func Positions(text string, substring string) []bool {
+	return findPositions(text, substring)
+}
+
+// ApplyPositions applies ANSI color codes to the characters marked in positions.
+//
+// It is ApplyColor with the matches already computed, for example with Positions.
+//
+// Parameters:
+//   - asciiArt: rendered ASCII art lines to be colorized
+//   - positions: the characters to color, indexed like charWidths
+//   - colorCode: ANSI escape sequence that starts the coloring
+//   - charWidths: column widths corresponding to each character of the text
+//
+// Returns:
+//   - A new slice of strings containing the colored ASCII art
+func ApplyPositions(asciiArt []string, positions []bool, colorCode string, charWidths []int) []string {
+	if len(asciiArt) == 0 || len(charWidths) == 0 || len(positions) == 0 {
+		return asciiArt
+	}
+
+	if len(positions) < len(charWidths) {
+		positions = append(positions[:len(positions):len(positions)], make([]bool, len(charWidths)-len(positions))...)
+	}
+
 	result := make([]string, len(asciiArt))
 
 	for i, line := range asciiArt {
@@ -54,7 +94,8 @@ func ApplyColor(
 // It uses the boolean positions slice to determine where coloring should
 // start and end, based on character boundaries defined by charWidths.
 // This function assumes that positions corresponds to indexes in the
-// original text, not byte offsets in the ASCII art.
+// original text, not byte offsets in the ASCII art. Widths count columns,
+// so glyphs drawn with multi-byte characters such as '█' are split correctly.
 //
 // Parameters:
 //   - line: The ASCII art line to colorize.
@@ -78,9 +119,10 @@ func colorLine(
 			break
 		}
 
-		end := offset + width
-		if end > len(line) {
-			end = len(line)
+		end := offset
+		for n := 0; n < width && end < len(line); n++ {
+			_, size := utf8.DecodeRuneInString(line[end:])
+			end += size
 		}
 
 		isStart := positions[idx] && (idx == 0 || !positions[idx-1])
@@ -148,3 +190,78 @@ func findPositions(text string, substring string) []bool {
 
 	return positions
 }
+
+// ColumnMask reports, for every column of rendered ASCII art, whether the
+// column belongs to a character that should be colored.
+//
+// It is the column-level form of the selection made by ApplyPositions, for
+// callers that color art cell by cell with ColorCells.
+//
+// Parameters:
+//   - positions: the characters to color, as returned by Positions
+//   - charWidths: column widths corresponding to each character of the text
+//
+// Returns:
+//   - One entry per column of the rendered art, true for selected columns
+func ColumnMask(positions []bool, charWidths []int) []bool {
+	var mask []bool
+
+	for idx, width := range charWidths {
+		selected := idx < len(positions) && positions[idx]
+		for n := 0; n < width; n++ {
+			mask = append(mask, selected)
+		}
+	}
+
+	return mask
+}
+
+// ColorCells applies ANSI color codes to ASCII art cell by cell.
+//
+// Each run of neighboring cells with the same non-empty code is wrapped in that
+// code and Reset, so differently colored layers of the art, such as text and its
+// shadow, can sit side by side on one line.
+//
+// Parameters:
+//   - lines: ASCII art lines to be colorized
+//   - codes: the color code of every cell, indexed by line and column; "" and
+//     cells beyond the end of a row are left uncolored
+//
+// Returns:
+//   - A new slice of strings containing the colored ASCII art
+func ColorCells(lines []string, codes [][]string) []string {
+	result := make([]string, len(lines))
+
+	for i, line := range lines {
+		var row []string
+		if i < len(codes) {
+			row = codes[i]
+		}
+
+		var builder strings.Builder
+		current := ""
+		x := 0
+		for _, r := range line {
+			code := ""
+			if x < len(row) {
+				code = row[x]
+			}
+			if code != current {
+				if current != "" {
+					builder.WriteString(Reset)
+				}
+				builder.WriteString(code)
+				current = code
+			}
+			builder.WriteRune(r)
+			x++
+		}
+		if current != "" {
+			builder.WriteString(Reset)
+		}
+
+		result[i] = builder.String()
+	}
+
+	return result
+}
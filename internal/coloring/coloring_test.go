@@ -138,3 +138,59 @@ func TestApplyColor_FinalCoverage(t *testing.T) {
 		}
 	})
 }
+
+func TestApplyColor_MultiByteGlyphs(t *testing.T) {
+	colorCode := "\033[31m"
+	art := []string{"█▀ ██", "▄█ █▄"}
+
+	got := coloring.ApplyColor(art, "ab", "b", colorCode, []int{3, 2})
+	want := []string{"█▀ " + colorCode + "██" + coloring.Reset, "▄█ " + colorCode + "█▄" + coloring.Reset}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestColumnMask(t *testing.T) {
+	got := coloring.ColumnMask(coloring.Positions("abc", "b"), []int{2, 3, 1})
+	want := []bool{false, false, true, true, true, false}
+	if len(got) != len(want) {
+		t.Fatalf("got %d columns, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("column %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestColorCells(t *testing.T) {
+	red, gray := "\033[31m", "\033[90m"
+	lines := []string{"█▀░ x", "ab"}
+	codes := [][]string{{red, red, gray}, nil}
+
+	got := coloring.ColorCells(lines, codes)
+	want := []string{red + "█▀" + coloring.Reset + gray + "░" + coloring.Reset + " x", "ab"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestApplyPositions(t *testing.T) {
+	colorCode := "\033[31m"
+	// The matches of "lo w" in "hello world", sliced for the wrapped segment "world".
+	positions := coloring.Positions("hello world", "lo w")[6:]
+
+	got := coloring.ApplyPositions([]string{"world"}, positions, colorCode, []int{1, 1, 1, 1, 1})
+	if want := colorCode + "w" + coloring.Reset + "orld"; got[0] != want {
+		t.Errorf("got %q, want %q", got[0], want)
+	}
+
+	got = coloring.ApplyPositions([]string{"ab"}, []bool{true}, colorCode, []int{1, 1})
+	if want := colorCode + "a" + coloring.Reset + "b"; got[0] != want {
+		t.Errorf("short positions: got %q, want %q", got[0], want)
+	}
+}
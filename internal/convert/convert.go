@@ -0,0 +1,465 @@
+// Package convert writes banners in the formats the parser reads.
+//
+// A banner loaded from any supported format can be written as the project's txt
+// format (95 printable ASCII glyphs followed by code point tagged sections), as
+// a FIGlet font with a complete "flf2a" header, or as a JSON document mapping
+// code points to glyph rows, each carrying the banner's metadata. Loading the
+// output with the parser yields the original banner whenever the target format
+// can represent it; every glyph that cannot be written exactly is reported as
+// a Problem.
+//
+// Responsibilities of this package:
+//   - Select the output format by name or file extension
+//   - Serialize banners to txt, FIGlet and JSON
+//   - Report glyphs that are missing, adjusted or skipped in the output
+package convert
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"ascii-art-fs/internal/parser"
+)
+
+// Format names an output banner format.
+type Format string
+
+const (
+	// Txt is the project's banner format.
+	Txt Format = "txt"
+	// FIGlet is the FIGlet font format (.flf).
+	FIGlet Format = "flf"
+	// JSON maps code points to glyph rows.
+	JSON Format = "json"
+)
+
+// formats lists the supported formats in the order they are presented to users.
+var formats = []Format{Txt, FIGlet, JSON}
+
+const (
+	firstPrintable rune = 32
+	lastPrintable  rune = 126
+
+	// figletComment is the comment line written into converted FIGlet fonts.
+	figletComment = "Converted by ascii-art convert"
+)
+
+// hardblankCandidates and endmarkCandidates are tried in order when choosing
+// FIGlet marker characters that do not clash with the glyphs.
+const (
+	hardblankCandidates = "$#%&~^*+=!?"
+	endmarkCandidates   = "@#$%&|!~^*+="
+)
+
+// Problem describes a glyph that could not be written exactly.
+type Problem struct {
+	Rune   rune
+	Reason string
+}
+
+// String formats the problem as "glyph 'c' (U+XXXX): reason".
+func (p Problem) String() string {
+	return fmt.Sprintf("glyph %q (U+%04X): %s", p.Rune, p.Rune, p.Reason)
+}
+
+// ParseFormat returns the format with the given name.
+//
+// Parameters:
+//   - name: A format name: "txt", "flf" (or "figlet") or "json".
+//
+// Returns:
+//   - The format.
+//   - An error listing the valid names if name is unknown.
+func ParseFormat(name string) (Format, error) {
+	switch f := Format(strings.ToLower(name)); f {
+	case Txt, FIGlet, JSON:
+		return f, nil
+	case "figlet":
+		return FIGlet, nil
+	}
+	names := make([]string, len(formats))
+	for i, f := range formats {
+		names[i] = string(f)
+	}
+	return "", fmt.Errorf("unknown banner format %q (valid formats: %s)", name, strings.Join(names, ", "))
+}
+
+// FormatForPath returns the format matching the extension of a file path.
+//
+// Parameters:
+//   - path: The output file path.
+//
+// Returns:
+//   - The format.
+//   - An error if the extension does not name a supported format.
+func FormatForPath(path string) (Format, error) {
+	ext := strings.TrimPrefix(filepath.Ext(path), ".")
+	if ext == "" {
+		return "", fmt.Errorf("cannot tell the banner format of %q from its extension", path)
+	}
+	return ParseFormat(ext)
+}
+
+// Write writes a banner in the given format.
+//
+// All glyphs are written with the height of the tallest glyph; shorter glyphs
+// are padded at the bottom and reported. The metadata is written as a front
+// matter header in txt, as a "metadata" object in JSON and as a block of
+// comment lines in FIGlet; a declared height is replaced by the written one.
+//
+// Parameters:
+//   - w: The destination.
+//   - banner: The banner to write.
+//   - meta: The banner's metadata; the zero Metadata writes none.
+//   - format: The output format.
+//
+// Returns:
+//   - The glyphs that could not be written exactly, in code point order.
+//   - An error if the format is unknown, the banner is empty or writing fails.
+func Write(w io.Writer, banner parser.Banner, meta parser.Metadata, format Format) ([]Problem, error) {
+	if len(banner) == 0 {
+		return nil, fmt.Errorf("banner defines no glyphs")
+	}
+	glyphs, height, problems := equalizeHeights(banner)
+	if meta.Height != 0 {
+		meta.Height = height
+	}
+
+	bw := bufio.NewWriter(w)
+	var err error
+	switch format {
+	case Txt:
+		problems = append(problems, writeTxt(bw, glyphs, meta, height)...)
+	case FIGlet:
+		problems = append(problems, writeFIGlet(bw, glyphs, meta, height)...)
+	case JSON:
+		var jsonProblems []Problem
+		jsonProblems, err = writeJSON(bw, glyphs, meta, height)
+		problems = append(problems, jsonProblems...)
+	default:
+		return nil, fmt.Errorf("unknown banner format %q", format)
+	}
+	if err == nil {
+		err = bw.Flush()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write %s banner: %w", format, err)
+	}
+
+	slices.SortStableFunc(problems, func(a, b Problem) int { return cmp.Compare(a.Rune, b.Rune) })
+	return problems, nil
+}
+
+// equalizeHeights pads every glyph at the bottom to the height of the tallest one.
+//
+// Parameters:
+//   - banner: The banner to write.
+//
+// Returns:
+//   - A copy of the banner whose glyphs all have the same height.
+//   - That height.
+//   - A problem for every padded glyph.
+func equalizeHeights(banner parser.Banner) (parser.Banner, int, []Problem) {
+	height := 0
+	for _, rows := range banner {
+		height = max(height, len(rows))
+	}
+
+	var problems []Problem
+	glyphs := make(parser.Banner, len(banner))
+	for r, rows := range banner {
+		if len(rows) < height {
+			problems = append(problems, Problem{r, fmt.Sprintf("padded from %d to %d rows", len(rows), height)})
+			width := 0
+			if len(rows) > 0 {
+				width = len(rows[0])
+			}
+			rows = slices.Clone(rows)
+			for len(rows) < height {
+				rows = append(rows, strings.Repeat(" ", width))
+			}
+		}
+		glyphs[r] = rows
+	}
+	return glyphs, height, problems
+}
+
+// sortedRunes returns the code points defined by a banner in ascending order.
+func sortedRunes(banner parser.Banner) []rune {
+	runes := make([]rune, 0, len(banner))
+	for r := range banner {
+		runes = append(runes, r)
+	}
+	slices.Sort(runes)
+	return runes
+}
+
+// writeTxt writes the txt format: the metadata header, the 95 printable ASCII
+// glyphs, each after a blank separator, then every other glyph in sections
+// tagged with their code point or range of consecutive code points.
+//
+// Parameters:
+//   - w: The destination.
+//   - glyphs: Glyphs of equal height.
+//   - meta: The metadata.
+//   - height: The glyph height.
+//
+// Returns:
+//   - Glyphs that are missing, skipped or read back differently.
+func writeTxt(w *bufio.Writer, glyphs parser.Banner, meta parser.Metadata, height int) []Problem {
+	var problems []Problem
+	empty := make([]string, height)
+	lines := parser.FormatFrontMatter(meta)
+
+	for r := firstPrintable; r <= lastPrintable; r++ {
+		rows, ok := glyphs[r]
+		if !ok {
+			problems = append(problems, Problem{r, "missing; written as an empty placeholder glyph"})
+			rows = empty
+		}
+		lines = append(lines, "")
+		lines = append(lines, rows...)
+	}
+
+	var extended []rune
+	for _, r := range sortedRunes(glyphs) {
+		switch {
+		case r >= firstPrintable && r <= lastPrintable:
+		case !utf8.ValidRune(r) || unicode.IsControl(r):
+			problems = append(problems, Problem{r, "control and invalid code points cannot be tagged in txt banners; skipped"})
+		default:
+			if isEmptyGlyph(glyphs[r]) {
+				problems = append(problems, Problem{r, "glyph of empty rows is read back as a placeholder; skipped"})
+				continue
+			}
+			extended = append(extended, r)
+		}
+	}
+
+	for start := 0; start < len(extended); {
+		end := start
+		for end+1 < len(extended) && extended[end+1] == extended[end]+1 {
+			end++
+		}
+		tag := fmt.Sprintf("U+%04X", extended[start])
+		if end > start {
+			tag += fmt.Sprintf("..U+%04X", extended[end])
+		}
+		for i := start; i <= end; i++ {
+			if i == start {
+				lines = append(lines, tag)
+			} else {
+				lines = append(lines, "")
+			}
+			lines = append(lines, glyphs[extended[i]]...)
+		}
+		start = end + 1
+	}
+
+	for _, line := range lines {
+		w.WriteString(line)
+		w.WriteByte('\n')
+	}
+	return problems
+}
+
+// writeFIGlet writes a FIGlet font: the header, a comment line followed by the
+// metadata block, the 95 printable ASCII glyphs, the seven German glyphs and
+// every other glyph after a code tag line. The header declares the metadata's
+// baseline, or the glyph height if none is declared.
+//
+// The hardblank and endmark characters are chosen so that they do not occur
+// in the glyphs. Missing ASCII glyphs and German glyphs are written with zero
+// width, which the FIGlet format reserves for undefined characters; the
+// parser reads missing German glyphs back as undefined.
+//
+// Parameters:
+//   - w: The destination.
+//   - glyphs: Glyphs of equal height.
+//   - meta: The metadata.
+//   - height: The glyph height.
+//
+// Returns:
+//   - Glyphs that are missing or read back differently.
+func writeFIGlet(w *bufio.Writer, glyphs parser.Banner, meta parser.Metadata, height int) []Problem {
+	var problems []Problem
+	hardblank := pickMarker(glyphs, hardblankCandidates, strings.Contains)
+	endmark := pickMarker(glyphs, endmarkCandidates, strings.HasSuffix)
+
+	maxLength := 0
+	for _, rows := range glyphs {
+		for _, row := range rows {
+			maxLength = max(maxLength, len(row))
+		}
+	}
+	baseline := height
+	if meta.Baseline != 0 && meta.Baseline <= height {
+		baseline = meta.Baseline
+	}
+	comments := append([]string{figletComment}, parser.FormatFrontMatter(meta)...)
+	fmt.Fprintf(w, "flf2a%c %d %d %d -1 %d\n", hardblank, height, baseline, maxLength+2, len(comments))
+	for _, comment := range comments {
+		fmt.Fprintln(w, comment)
+	}
+
+	writeGlyph := func(rows []string) {
+		for i, row := range rows {
+			w.WriteString(row)
+			w.WriteByte(endmark)
+			if i == len(rows)-1 {
+				w.WriteByte(endmark)
+			}
+			w.WriteByte('\n')
+		}
+	}
+
+	empty := make([]string, height)
+	for r := firstPrintable; r <= lastPrintable; r++ {
+		rows, ok := glyphs[r]
+		if !ok {
+			problems = append(problems, Problem{r, "missing; written as an empty glyph"})
+			rows = empty
+		}
+		writeGlyph(rows)
+	}
+
+	deutsch := []rune{196, 214, 220, 228, 246, 252, 223}
+	hasExtra := false
+	for r := range glyphs {
+		if r < firstPrintable || r > lastPrintable {
+			hasExtra = true
+			break
+		}
+	}
+	if !hasExtra {
+		return append(problems, unevenRows(glyphs)...)
+	}
+	for _, r := range deutsch {
+		rows, ok := glyphs[r]
+		if !ok {
+			rows = empty
+		} else if isEmptyGlyph(rows) {
+			problems = append(problems, Problem{r, "glyph of empty rows is read back as undefined"})
+		}
+		writeGlyph(rows)
+	}
+	for _, r := range sortedRunes(glyphs) {
+		if (r >= firstPrintable && r <= lastPrintable) || slices.Contains(deutsch, r) {
+			continue
+		}
+		fmt.Fprintf(w, "0x%04X  U+%04X\n", r, r)
+		writeGlyph(glyphs[r])
+	}
+	return append(problems, unevenRows(glyphs)...)
+}
+
+// unevenRows reports glyphs whose rows differ in width; FIGlet readers pad
+// such rows to the widest one.
+func unevenRows(glyphs parser.Banner) []Problem {
+	var problems []Problem
+	for r, rows := range glyphs {
+		for _, row := range rows {
+			if len(row) != len(rows[0]) {
+				problems = append(problems, Problem{r, "rows of different widths are padded to the widest row"})
+				break
+			}
+		}
+	}
+	return problems
+}
+
+// pickMarker returns the first candidate character that clashes with no glyph
+// row, or the first candidate if all of them clash.
+//
+// Parameters:
+//   - glyphs: The glyphs to write.
+//   - candidates: Marker characters in order of preference.
+//   - clashes: Reports whether a row conflicts with a marker.
+//
+// Returns:
+//   - The marker character.
+func pickMarker(glyphs parser.Banner, candidates string, clashes func(row, marker string) bool) byte {
+	for i := 0; i < len(candidates); i++ {
+		marker := candidates[i : i+1]
+		if !anyRow(glyphs, func(row string) bool { return clashes(row, marker) }) {
+			return candidates[i]
+		}
+	}
+	return candidates[0]
+}
+
+// anyRow reports whether pred holds for any row of any glyph.
+func anyRow(glyphs parser.Banner, pred func(string) bool) bool {
+	for _, rows := range glyphs {
+		if slices.ContainsFunc(rows, pred) {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmptyGlyph reports whether every row of a glyph is an empty string.
+func isEmptyGlyph(rows []string) bool {
+	for _, row := range rows {
+		if row != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// writeJSON writes the JSON format: the glyph height, the glyph rows keyed by
+// code point in U+ notation and the metadata other than the height.
+//
+// Parameters:
+//   - w: The destination.
+//   - glyphs: Glyphs of equal height.
+//   - meta: The metadata.
+//   - height: The glyph height.
+//
+// Returns:
+//   - Glyphs that are missing or skipped.
+//   - An error if encoding fails.
+func writeJSON(w io.Writer, glyphs parser.Banner, meta parser.Metadata, height int) ([]Problem, error) {
+	var problems []Problem
+	for r := firstPrintable; r <= lastPrintable; r++ {
+		if _, ok := glyphs[r]; !ok {
+			problems = append(problems, Problem{r, "missing"})
+		}
+	}
+
+	doc := struct {
+		Height   int                 `json:"height"`
+		Glyphs   map[string][]string `json:"glyphs"`
+		Metadata map[string]string   `json:"metadata,omitempty"`
+	}{Height: height, Glyphs: make(map[string][]string, len(glyphs))}
+	for _, f := range meta.Fields() {
+		if f.Key == "height" {
+			continue // the document's height
+		}
+		if doc.Metadata == nil {
+			doc.Metadata = make(map[string]string)
+		}
+		doc.Metadata[f.Key] = f.Value
+	}
+	for r, rows := range glyphs {
+		if !utf8.ValidRune(r) || unicode.IsControl(r) {
+			problems = append(problems, Problem{r, "control and invalid code points cannot be keyed in JSON banners; skipped"})
+			continue
+		}
+		doc.Glyphs[fmt.Sprintf("U+%04X", r)] = rows
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return problems, enc.Encode(doc)
+}
@@ -0,0 +1,195 @@
+package convert
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"ascii-art-fs/internal/parser"
+)
+
+// loadTestBanner loads a banner from the CLI testdata directory.
+func loadTestBanner(t *testing.T, name string) parser.Banner {
+	t.Helper()
+	banner, err := parser.Load(os.DirFS("../../cmd/ascii-art/testdata"), name)
+	if err != nil {
+		t.Fatalf("failed to load %s: %v", name, err)
+	}
+	return banner
+}
+
+// roundTrip writes banner in format and loads the result with the parser.
+func roundTrip(t *testing.T, banner parser.Banner, format Format) (parser.Banner, []Problem) {
+	t.Helper()
+	var buf bytes.Buffer
+	problems, err := Write(&buf, banner, parser.Metadata{}, format)
+	if err != nil {
+		t.Fatalf("Write(%s) failed: %v", format, err)
+	}
+	name := "out." + string(format)
+	got, err := parser.Load(fstest.MapFS{name: {Data: buf.Bytes()}}, name)
+	if err != nil {
+		t.Fatalf("failed to load converted %s banner: %v\n%s", format, err, buf.String())
+	}
+	return got, problems
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	extended := loadTestBanner(t, "standard.txt")
+	extended['Ω'] = extended['O']
+	extended['Α'] = extended['A']
+	extended['Β'] = extended['B']
+	extended['é'] = extended['e']
+
+	banners := map[string]parser.Banner{
+		"standard":   loadTestBanner(t, "standard.txt"),
+		"thinkertoy": loadTestBanner(t, "thinkertoy.txt"),
+		"figlet":     loadTestBanner(t, "mini.flf"),
+		"extended":   extended,
+	}
+	for name, banner := range banners {
+		for _, format := range formats {
+			t.Run(name+"/"+string(format), func(t *testing.T) {
+				got, problems := roundTrip(t, banner, format)
+				if len(problems) != 0 {
+					t.Errorf("unexpected problems: %v", problems)
+				}
+				if !reflect.DeepEqual(got, banner) {
+					t.Error("converted banner differs from the original")
+				}
+			})
+		}
+	}
+}
+
+func TestWriteTxtLayout(t *testing.T) {
+	banner := loadTestBanner(t, "standard.txt")
+	banner['Α'] = banner['A']
+	banner['Β'] = banner['B']
+	banner['Ω'] = banner['O']
+
+	var buf bytes.Buffer
+	if _, err := Write(&buf, banner, parser.Metadata{}, Txt); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	if got := lines[855]; got != "U+0391..U+0392" {
+		t.Errorf("first tag = %q, want a range for Alpha and Beta", got)
+	}
+	if got := lines[855+18]; got != "U+03A9" {
+		t.Errorf("second tag = %q, want a single code point for Omega", got)
+	}
+}
+
+func TestWriteProblems(t *testing.T) {
+	banner := parser.Banner{
+		'A':      {"/\\", "||"},
+		'B':      {"B"},
+		0x7F:     {"<>", "<>"},
+		'Ω':      {"", ""},
+		'\u00C4': {"", ""},
+	}
+
+	tests := []struct {
+		format Format
+		want   []string
+	}{
+		{Txt, []string{"glyph ' ' (U+0020): missing", "glyph 'B' (U+0042): padded from 1 to 2 rows",
+			"U+007F): control", "glyph 'Ω' (U+03A9): glyph of empty rows"}},
+		{FIGlet, []string{"glyph ' ' (U+0020): missing", "glyph 'Ä' (U+00C4): glyph of empty rows"}},
+		{JSON, []string{"glyph ' ' (U+0020): missing", "U+007F): control"}},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		problems, err := Write(&buf, banner, parser.Metadata{}, tt.format)
+		if err != nil {
+			t.Fatalf("Write(%s) failed: %v", tt.format, err)
+		}
+		var all strings.Builder
+		for _, p := range problems {
+			all.WriteString(p.String() + "\n")
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(all.String(), want) {
+				t.Errorf("%s problems missing %q:\n%s", tt.format, want, all.String())
+			}
+		}
+		if _, _, err := parser.LoadPartial(fstest.MapFS{"out." + string(tt.format): {Data: buf.Bytes()}},
+			"out."+string(tt.format)); err != nil {
+			t.Errorf("%s output should load as a partial banner: %v", tt.format, err)
+		}
+	}
+
+	if _, err := Write(&bytes.Buffer{}, parser.Banner{}, parser.Metadata{}, Txt); err == nil {
+		t.Error("Write should reject an empty banner")
+	}
+}
+
+func TestWriteMetadataRoundTrip(t *testing.T) {
+	banner := loadTestBanner(t, "standard.txt")
+	meta := parser.Metadata{
+		Name:     "Standard",
+		Author:   "Alice",
+		License:  "MIT",
+		Height:   8,
+		Baseline: 6,
+		Coverage: []parser.CodePointRange{{First: ' ', Last: '~'}},
+		Extra:    map[string]string{"source": "https://example.com/standard"},
+	}
+	for _, format := range formats {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if _, err := Write(&buf, banner, meta, format); err != nil {
+				t.Fatalf("Write(%s) failed: %v", format, err)
+			}
+			fsys := fstest.MapFS{"out." + string(format): {Data: buf.Bytes()}}
+			if _, err := parser.Load(fsys, "out."+string(format)); err != nil {
+				t.Fatalf("failed to load converted banner: %v\n%s", err, buf.String())
+			}
+			got, err := parser.LoadMetadata(fsys, "out."+string(format), parser.Strict)
+			if err != nil || !reflect.DeepEqual(got, meta) {
+				t.Errorf("metadata = %+v, %v; want %+v", got, err, meta)
+			}
+		})
+	}
+}
+
+func TestWriteFIGletMarkers(t *testing.T) {
+	banner := loadTestBanner(t, "standard.txt")
+	banner['A'] = []string{"$$@", "$$@", "$$@", "$$@", "$$@", "$$@", "$$@", "$$@"}
+
+	got, problems := roundTrip(t, banner, FIGlet)
+	if len(problems) != 0 || !reflect.DeepEqual(got['A'], banner['A']) {
+		t.Errorf("glyph using the default markers = %q with problems %v", got['A'], problems)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Format
+		wantErr bool
+	}{
+		{"txt", Txt, false},
+		{"FLF", FIGlet, false},
+		{"figlet", FIGlet, false},
+		{"json", JSON, false},
+		{"bdf", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.name)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("ParseFormat(%q) = %q, %v; want %q, error %v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+
+	if got, err := FormatForPath("fonts/logo.flf"); got != FIGlet || err != nil {
+		t.Errorf("FormatForPath(logo.flf) = %q, %v", got, err)
+	}
+	if _, err := FormatForPath("logo"); err == nil {
+		t.Error("FormatForPath should reject a path without extension")
+	}
+}
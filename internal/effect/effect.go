@@ -0,0 +1,298 @@
+// Package effect decorates rendered ASCII art with drop shadows and outlines.
+//
+// Effects work on the cells of the rendered output rather than on banner
+// glyphs, so they apply to any banner: every cell that is not a space is ink,
+// and an effect adds decoration cells around the ink or changes the ink itself.
+// The result records the layer of every cell, which lets callers color the
+// decoration independently of the text.
+//
+// Responsibilities of this package:
+//   - Compute drop shadows with a configurable offset and character
+//   - Compute outlines around the ink and hollow versions of solid ink
+//   - Parse effect specifications such as "shadow:2,1,░"
+package effect
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultShadowChar is the character drawn for shadow cells.
+	DefaultShadowChar = '░'
+	// DefaultOutlineChar is the character drawn for outline cells.
+	DefaultOutlineChar = '.'
+	// MaxOffset is the largest shadow offset accepted by Parse.
+	MaxOffset = 8
+
+	argSeparator  = ":"
+	listSeparator = ","
+)
+
+// Layer tells what a cell of decorated art shows.
+type Layer uint8
+
+const (
+	// Background marks an empty cell.
+	Background Layer = iota
+	// Ink marks a cell of the original art.
+	Ink
+	// Decoration marks a cell added by an effect, such as a shadow.
+	Decoration
+)
+
+// Art is decorated ASCII art.
+//
+// Lines holds the rows, all padded to the same number of characters. Layers
+// holds the layer of every cell, indexed by row and character column. The
+// original art starts at column X and row Y of the result, since some effects
+// add cells above and to the left of it.
+type Art struct {
+	Lines  []string
+	Layers [][]Layer
+	X, Y   int
+}
+
+// Effect decorates rendered art given as its rows.
+type Effect func(lines []string) Art
+
+// grid is art as a matrix of characters with the layer of every cell.
+type grid struct {
+	cells  [][]rune
+	layers [][]Layer
+}
+
+// newGrid copies lines into a grid with a margin of blank cells.
+//
+// Parameters:
+//   - lines: The rendered rows.
+//   - left, top, right, bottom: The margin added on each side.
+//
+// Returns:
+//   - The grid, where non-space characters are Ink.
+func newGrid(lines []string, left, top, right, bottom int) grid {
+	width := 0
+	for _, line := range lines {
+		width = max(width, utf8.RuneCountInString(line))
+	}
+	width += left + right
+	height := len(lines) + top + bottom
+
+	g := grid{cells: make([][]rune, height), layers: make([][]Layer, height)}
+	for y := range g.cells {
+		g.cells[y] = []rune(strings.Repeat(" ", width))
+		g.layers[y] = make([]Layer, width)
+	}
+	for y, line := range lines {
+		x := left
+		for _, r := range line {
+			g.cells[y+top][x] = r
+			if r != ' ' {
+				g.layers[y+top][x] = Ink
+			}
+			x++
+		}
+	}
+	return g
+}
+
+// isInk reports whether the cell at x, y exists and holds ink.
+func (g grid) isInk(x, y int) bool {
+	return y >= 0 && y < len(g.cells) && x >= 0 && x < len(g.cells[y]) && g.layers[y][x] == Ink
+}
+
+// art converts the grid to Art with the original art at x, y.
+func (g grid) art(x, y int) Art {
+	lines := make([]string, len(g.cells))
+	for i, row := range g.cells {
+		lines[i] = string(row)
+	}
+	return Art{Lines: lines, Layers: g.layers, X: x, Y: y}
+}
+
+// None returns the art unchanged, with its layers.
+//
+// Parameters:
+//   - lines: The rendered rows.
+//
+// Returns:
+//   - The art, padded to a common width.
+func None(lines []string) Art {
+	return newGrid(lines, 0, 0, 0, 0).art(0, 0)
+}
+
+// Shadow returns an effect that casts a drop shadow: every ink cell draws char
+// dx columns to the right and dy rows below it, wherever that cell is empty.
+// The art grows by dx columns and dy rows.
+//
+// Parameters:
+//   - dx: The horizontal offset, at least 0.
+//   - dy: The vertical offset, at least 0.
+//   - char: The shadow character.
+//
+// Returns:
+//   - The shadow effect.
+func Shadow(dx, dy int, char rune) Effect {
+	return func(lines []string) Art {
+		g := newGrid(lines, 0, 0, dx, dy)
+		for y := len(g.cells) - 1; y >= 0; y-- {
+			for x := len(g.cells[y]) - 1; x >= 0; x-- {
+				if g.layers[y][x] == Background && g.isInk(x-dx, y-dy) {
+					g.cells[y][x] = char
+					g.layers[y][x] = Decoration
+				}
+			}
+		}
+		return g.art(0, 0)
+	}
+}
+
+// Outline returns an effect that draws char in every empty cell touching the
+// ink, including diagonally. The art grows by one cell on every side.
+//
+// Parameters:
+//   - char: The outline character.
+//
+// Returns:
+//   - The outline effect.
+func Outline(char rune) Effect {
+	return func(lines []string) Art {
+		g := newGrid(lines, 1, 1, 1, 1)
+		for y := range g.cells {
+			for x := range g.cells[y] {
+				if g.layers[y][x] == Background && g.touchesInk(x, y, true) {
+					g.cells[y][x] = char
+					g.layers[y][x] = Decoration
+				}
+			}
+		}
+		return g.art(1, 1)
+	}
+}
+
+// Hollow clears the inside of solid ink, keeping only the ink cells that touch
+// an empty cell above, below, left or right. It suits banners drawn with
+// filled blocks, such as imported bitmap fonts.
+//
+// Parameters:
+//   - lines: The rendered rows.
+//
+// Returns:
+//   - The hollow art.
+func Hollow(lines []string) Art {
+	g := newGrid(lines, 0, 0, 0, 0)
+	var inside [][2]int
+	for y := range g.cells {
+		for x := range g.cells[y] {
+			if g.layers[y][x] == Ink && !g.touchesBlank(x, y) {
+				inside = append(inside, [2]int{x, y})
+			}
+		}
+	}
+	for _, cell := range inside {
+		x, y := cell[0], cell[1]
+		g.cells[y][x] = ' '
+		g.layers[y][x] = Background
+	}
+	return g.art(0, 0)
+}
+
+// touchesInk reports whether a neighbor of the cell at x, y holds ink.
+func (g grid) touchesInk(x, y int, diagonal bool) bool {
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			if (dx == 0 && dy == 0) || (!diagonal && dx != 0 && dy != 0) {
+				continue
+			}
+			if g.isInk(x+dx, y+dy) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// touchesBlank reports whether the cell at x, y is on the edge of the art or
+// has an empty cell above, below, left or right of it.
+func (g grid) touchesBlank(x, y int) bool {
+	for _, d := range [][2]int{{0, -1}, {0, 1}, {-1, 0}, {1, 0}} {
+		if !g.isInk(x+d[0], y+d[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse builds an effect from its specification:
+//
+//   - "shadow", "shadow:<dx>,<dy>" or "shadow:<dx>,<dy>,<char>" (default 1,1,░)
+//   - "outline" or "outline:<char>" (default '.')
+//   - "hollow"
+//
+// Parameters:
+//   - spec: The effect specification.
+//
+// Returns:
+//   - The effect.
+//   - An error if the name or an argument is invalid.
+func Parse(spec string) (Effect, error) {
+	name, args, hasArgs := strings.Cut(spec, argSeparator)
+	switch strings.ToLower(name) {
+	case "shadow":
+		dx, dy, char := 1, 1, DefaultShadowChar
+		if hasArgs {
+			parts := strings.Split(args, listSeparator)
+			if len(parts) < 2 || len(parts) > 3 {
+				return nil, fmt.Errorf("invalid effect %q: expected shadow:<dx>,<dy>[,<char>]", spec)
+			}
+			var err error
+			if dx, err = parseOffset(parts[0]); err != nil {
+				return nil, fmt.Errorf("invalid effect %q: %w", spec, err)
+			}
+			if dy, err = parseOffset(parts[1]); err != nil {
+				return nil, fmt.Errorf("invalid effect %q: %w", spec, err)
+			}
+			if len(parts) == 3 {
+				if char, err = parseChar(parts[2]); err != nil {
+					return nil, fmt.Errorf("invalid effect %q: %w", spec, err)
+				}
+			}
+		}
+		return Shadow(dx, dy, char), nil
+	case "outline":
+		char := DefaultOutlineChar
+		if hasArgs {
+			var err error
+			if char, err = parseChar(args); err != nil {
+				return nil, fmt.Errorf("invalid effect %q: %w", spec, err)
+			}
+		}
+		return Outline(char), nil
+	case "hollow":
+		if hasArgs {
+			return nil, fmt.Errorf("invalid effect %q: hollow takes no arguments", spec)
+		}
+		return Hollow, nil
+	}
+	return nil, fmt.Errorf("unknown effect %q: expected shadow, outline or hollow", name)
+}
+
+// parseOffset parses a shadow offset between 0 and MaxOffset.
+func parseOffset(s string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || n < 0 || n > MaxOffset {
+		return 0, fmt.Errorf("offset must be a number from 0 to %d, got %q", MaxOffset, s)
+	}
+	return n, nil
+}
+
+// parseChar parses a single visible effect character.
+func parseChar(s string) (rune, error) {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 || size != len(s) || r == ' ' || r == utf8.RuneError {
+		return 0, fmt.Errorf("expected a single visible character, got %q", s)
+	}
+	return r, nil
+}
@@ -0,0 +1,116 @@
+package effect
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEffects(t *testing.T) {
+	tests := []struct {
+		name      string
+		effect    Effect
+		lines     []string
+		want      []string
+		wantX     int
+		wantY     int
+		wantLayer [3]int // counts of Background, Ink and Decoration cells
+	}{
+		{
+			name:      "shadow",
+			effect:    Shadow(1, 1, '░'),
+			lines:     []string{"/\\", "\\/"},
+			want:      []string{"/\\ ", "\\/░", " ░░"},
+			wantLayer: [3]int{2, 4, 3},
+		},
+		{
+			name:      "shadow two columns right",
+			effect:    Shadow(2, 0, ':'),
+			lines:     []string{"|_", "|"},
+			want:      []string{"|_::", "| : "},
+			wantLayer: [3]int{2, 3, 3},
+		},
+		{
+			name:      "outline",
+			effect:    Outline('.'),
+			lines:     []string{"#"},
+			want:      []string{"...", ".#.", "..."},
+			wantX:     1,
+			wantY:     1,
+			wantLayer: [3]int{0, 1, 8},
+		},
+		{
+			name:      "hollow",
+			effect:    Hollow,
+			lines:     []string{"███", "███", "███"},
+			want:      []string{"███", "█ █", "███"},
+			wantLayer: [3]int{1, 8, 0},
+		},
+		{
+			name:      "none pads rows",
+			effect:    None,
+			lines:     []string{"ab", "c"},
+			want:      []string{"ab", "c "},
+			wantLayer: [3]int{1, 3, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.effect(tt.lines)
+			if !reflect.DeepEqual(got.Lines, tt.want) {
+				t.Errorf("lines = %q, want %q", got.Lines, tt.want)
+			}
+			if got.X != tt.wantX || got.Y != tt.wantY {
+				t.Errorf("origin = %d,%d, want %d,%d", got.X, got.Y, tt.wantX, tt.wantY)
+			}
+			var counts [3]int
+			for y, row := range got.Layers {
+				if len(row) != len([]rune(got.Lines[y])) {
+					t.Fatalf("row %d has %d layers for %q", y, len(row), got.Lines[y])
+				}
+				for _, layer := range row {
+					counts[layer]++
+				}
+			}
+			if counts != tt.wantLayer {
+				t.Errorf("layer counts = %v, want %v", counts, tt.wantLayer)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    []string
+		wantErr string
+	}{
+		{spec: "shadow", want: []string{"# ", " ░"}},
+		{spec: "Shadow:0,1,*", want: []string{"#", "*"}},
+		{spec: "outline:+", want: []string{"+++", "+#+", "+++"}},
+		{spec: "hollow", want: []string{"#"}},
+		{spec: "glow", wantErr: `unknown effect "glow"`},
+		{spec: "shadow:1", wantErr: "expected shadow:<dx>,<dy>[,<char>]"},
+		{spec: "shadow:1,9", wantErr: "offset must be a number from 0 to 8"},
+		{spec: "shadow:1,1,ab", wantErr: "expected a single visible character"},
+		{spec: "outline: ", wantErr: "expected a single visible character"},
+		{spec: "hollow:x", wantErr: "hollow takes no arguments"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			effect, err := Parse(tt.spec)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Parse(%q) error = %v, want %q", tt.spec, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tt.spec, err)
+			}
+			if got := effect([]string{"#"}).Lines; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) renders %q, want %q", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
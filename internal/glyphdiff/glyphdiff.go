@@ -0,0 +1,282 @@
+// Package glyphdiff compares two banners glyph by glyph.
+//
+// A textual diff of a banner file shows which lines changed, not which
+// characters look different. Compare lists every code point whose glyph was
+// changed, added or removed, and Write draws the old and new glyphs side by
+// side with the changed cells highlighted, followed by a summary of the glyph
+// width changes, since those move everything rendered after the glyph.
+//
+// Responsibilities of this package:
+//   - Find the glyphs that differ between two banners
+//   - Locate the changed cells of a glyph
+//   - Write side-by-side glyph comparisons, with ANSI colors or with markers
+package glyphdiff
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"ascii-art-fs/internal/coloring"
+	"ascii-art-fs/internal/parser"
+)
+
+const (
+	// removedCode highlights changed cells of the old glyph.
+	removedCode = "\033[41m"
+	// addedCode highlights changed cells of the new glyph.
+	addedCode = "\033[42m"
+	// marker flags changed cells when colors are off.
+	marker = '^'
+	// columnGap separates the old, new and marker columns.
+	columnGap = " │ "
+)
+
+// Kind tells how a glyph differs between two banners.
+type Kind int
+
+const (
+	// Changed marks a glyph present in both banners with different rows.
+	Changed Kind = iota
+	// Added marks a glyph only the new banner defines.
+	Added
+	// Removed marks a glyph only the old banner defines.
+	Removed
+)
+
+// String returns the lowercase name of the kind.
+func (k Kind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	default:
+		return "changed"
+	}
+}
+
+// Change describes one glyph that differs between two banners.
+type Change struct {
+	Rune rune
+	Kind Kind
+	// Old and New are the glyph rows; Old is nil for added glyphs and New for
+	// removed ones.
+	Old, New []string
+}
+
+// OldWidth returns the width of the old glyph in characters, 0 if it was added.
+func (c Change) OldWidth() int {
+	return glyphWidth(c.Old)
+}
+
+// NewWidth returns the width of the new glyph in characters, 0 if it was removed.
+func (c Change) NewWidth() int {
+	return glyphWidth(c.New)
+}
+
+// Options controls how Write draws changes.
+type Options struct {
+	// Color highlights changed cells with ANSI background colors. Without it,
+	// a third column marks changed cells with '^'.
+	Color bool
+}
+
+// Compare lists the glyphs that differ between two banners.
+//
+// Parameters:
+//   - old: The banner before the change.
+//   - new: The banner after the change.
+//
+// Returns:
+//   - The differing glyphs, in code point order; empty when the banners match.
+func Compare(old, new parser.Banner) []Change {
+	var changes []Change
+	for r, oldRows := range old {
+		newRows, ok := new[r]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Rune: r, Kind: Removed, Old: oldRows})
+		case !slices.Equal(oldRows, newRows):
+			changes = append(changes, Change{Rune: r, Kind: Changed, Old: oldRows, New: newRows})
+		}
+	}
+	for r, newRows := range new {
+		if _, ok := old[r]; !ok {
+			changes = append(changes, Change{Rune: r, Kind: Added, New: newRows})
+		}
+	}
+	slices.SortFunc(changes, func(a, b Change) int { return int(a.Rune - b.Rune) })
+	return changes
+}
+
+// ChangedCells compares two glyphs cell by cell. Rows and glyphs of different
+// sizes are compared as if padded with spaces.
+//
+// Parameters:
+//   - old: The old glyph rows, or nil.
+//   - new: The new glyph rows, or nil.
+//
+// Returns:
+//   - For every row and column of the larger glyph, whether the cell differs.
+func ChangedCells(old, new []string) [][]bool {
+	height := max(len(old), len(new))
+	width := max(glyphWidth(old), glyphWidth(new))
+	cells := make([][]bool, height)
+	for y := range cells {
+		oldRow, newRow := paddedRow(old, y, width), paddedRow(new, y, width)
+		cells[y] = make([]bool, width)
+		for x := range cells[y] {
+			cells[y][x] = oldRow[x] != newRow[x]
+		}
+	}
+	return cells
+}
+
+// Write draws every change, then a summary of the number of changes and of
+// every glyph whose width changed.
+//
+// Each change gets a heading such as "'A' (U+0041) changed, width 6 -> 7",
+// then the old and new glyphs side by side with the changed cells highlighted.
+//
+// Parameters:
+//   - w: The destination.
+//   - changes: The changes from Compare.
+//   - opts: How to highlight changed cells.
+//
+// Returns:
+//   - An error if writing to w fails.
+func Write(w io.Writer, changes []Change, opts Options) error {
+	if len(changes) == 0 {
+		_, err := io.WriteString(w, "No glyph differences.\n")
+		return err
+	}
+
+	var out strings.Builder
+	for _, c := range changes {
+		writeChange(&out, c, opts)
+		out.WriteString("\n")
+	}
+	writeSummary(&out, changes)
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// writeChange draws one change under its heading.
+func writeChange(out *strings.Builder, c Change, opts Options) {
+	fmt.Fprintf(out, "%s %s, width %s\n", describeRune(c.Rune), c.Kind, describeWidths(c))
+
+	cells := ChangedCells(c.Old, c.New)
+	oldWidth := max(c.OldWidth(), len("old"))
+	newWidth := max(c.NewWidth(), len("new"))
+
+	heading := []string{pad("old", oldWidth), pad("new", newWidth)}
+	if !opts.Color {
+		heading = append(heading, "changes")
+	}
+	out.WriteString(strings.TrimRight("  "+strings.Join(heading, columnGap), " ") + "\n")
+
+	for y, changed := range cells {
+		oldRow := highlight(paddedRow(c.Old, y, oldWidth), changed, opts.Color, removedCode)
+		newRow := highlight(paddedRow(c.New, y, newWidth), changed, opts.Color, addedCode)
+		columns := []string{oldRow, newRow}
+		if !opts.Color {
+			marks := make([]rune, len(changed))
+			for x, ch := range changed {
+				marks[x] = ' '
+				if ch {
+					marks[x] = marker
+				}
+			}
+			columns = append(columns, string(marks))
+		}
+		out.WriteString(strings.TrimRight("  "+strings.Join(columns, columnGap), " ") + "\n")
+	}
+}
+
+// writeSummary counts the changes by kind and lists the width changes.
+func writeSummary(out *strings.Builder, changes []Change) {
+	var counts [3]int
+	var widths []string
+	for _, c := range changes {
+		counts[c.Kind]++
+		if c.OldWidth() != c.NewWidth() {
+			widths = append(widths, fmt.Sprintf("  %s: %s (%+d)",
+				describeRune(c.Rune), describeWidths(c), c.NewWidth()-c.OldWidth()))
+		}
+	}
+
+	fmt.Fprintf(out, "Summary: %d changed, %d added, %d removed\n", counts[Changed], counts[Added], counts[Removed])
+	if len(widths) == 0 {
+		out.WriteString("Width changes: none\n")
+		return
+	}
+	out.WriteString("Width changes:\n")
+	for _, line := range widths {
+		out.WriteString(line + "\n")
+	}
+}
+
+// highlight colors the changed cells of a padded row. Without colors the row
+// is returned as is.
+func highlight(row []rune, changed []bool, color bool, code string) string {
+	if !color {
+		return string(row)
+	}
+	codes := make([]string, len(row))
+	for x := range row {
+		if x < len(changed) && changed[x] {
+			codes[x] = code
+		}
+	}
+	return coloring.ColorCells([]string{string(row)}, [][]string{codes})[0]
+}
+
+// describeRune formats a code point like "'A' (U+0041)".
+func describeRune(r rune) string {
+	return fmt.Sprintf("%q (U+%04X)", r, r)
+}
+
+// describeWidths formats the old and new widths of a change, like "6 -> 7".
+func describeWidths(c Change) string {
+	switch c.Kind {
+	case Added:
+		return fmt.Sprintf("- -> %d", c.NewWidth())
+	case Removed:
+		return fmt.Sprintf("%d -> -", c.OldWidth())
+	}
+	if c.OldWidth() == c.NewWidth() {
+		return fmt.Sprintf("%d", c.OldWidth())
+	}
+	return fmt.Sprintf("%d -> %d", c.OldWidth(), c.NewWidth())
+}
+
+// glyphWidth returns the number of characters in the widest row of a glyph.
+func glyphWidth(rows []string) int {
+	width := 0
+	for _, row := range rows {
+		width = max(width, utf8.RuneCountInString(row))
+	}
+	return width
+}
+
+// paddedRow returns row y of a glyph as characters padded with spaces to width.
+// Rows beyond the glyph's height are blank.
+func paddedRow(rows []string, y, width int) []rune {
+	var row []rune
+	if y < len(rows) {
+		row = []rune(rows[y])
+	}
+	for len(row) < width {
+		row = append(row, ' ')
+	}
+	return row
+}
+
+// pad pads s with spaces to width characters.
+func pad(s string, width int) string {
+	return s + strings.Repeat(" ", max(0, width-utf8.RuneCountInString(s)))
+}
@@ -0,0 +1,102 @@
+package glyphdiff
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"ascii-art-fs/internal/parser"
+)
+
+var (
+	oldBanner = parser.Banner{
+		'a': {" _ ", "(_|"},
+		'b': {"|_ ", "|_)"},
+		'c': {" _", "(_"},
+	}
+	newBanner = parser.Banner{
+		'a': {" _ ", "(_|"},
+		'b': {"|_  ", "|__)"},
+		'd': {" _|", "(_|"},
+	}
+)
+
+func TestCompare(t *testing.T) {
+	changes := Compare(oldBanner, newBanner)
+	var got []string
+	for _, c := range changes {
+		got = append(got, string(c.Rune)+" "+c.Kind.String())
+	}
+	want := []string{"b changed", "c removed", "d added"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Compare = %q, want %q", got, want)
+	}
+	if w := changes[0]; w.OldWidth() != 3 || w.NewWidth() != 4 {
+		t.Errorf("widths of 'b' = %d, %d, want 3, 4", w.OldWidth(), w.NewWidth())
+	}
+	if len(Compare(oldBanner, oldBanner)) != 0 {
+		t.Error("a banner differs from itself")
+	}
+}
+
+func TestChangedCells(t *testing.T) {
+	got := ChangedCells([]string{"|_", "|_)"}, []string{"|_  ", "|__)"})
+	want := [][]bool{{false, false, false, false}, {false, false, true, true}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ChangedCells = %v, want %v", got, want)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	var out strings.Builder
+	if err := Write(&out, Compare(oldBanner, newBanner), Options{}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	want := strings.Join([]string{
+		"'b' (U+0062) changed, width 3 -> 4",
+		"  old │ new  │ changes",
+		"  |_  │ |_   │",
+		"  |_) │ |__) │   ^^",
+		"",
+		"'c' (U+0063) removed, width 2 -> -",
+		"  old │ new │ changes",
+		"   _  │     │  ^",
+		"  (_  │     │ ^^",
+		"",
+		"'d' (U+0064) added, width - -> 3",
+		"  old │ new │ changes",
+		"      │  _| │  ^^",
+		"      │ (_| │ ^^^",
+		"",
+		"Summary: 1 changed, 1 added, 1 removed",
+		"Width changes:",
+		"  'b' (U+0062): 3 -> 4 (+1)",
+		"  'c' (U+0063): 2 -> - (-2)",
+		"  'd' (U+0064): - -> 3 (+3)",
+		"",
+	}, "\n")
+	if out.String() != want {
+		t.Errorf("Write output:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestWriteColor(t *testing.T) {
+	var out strings.Builder
+	changes := []Change{{Rune: 'x', Kind: Changed, Old: []string{"\\/"}, New: []string{"\\|"}}}
+	if err := Write(&out, changes, Options{Color: true}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if strings.Contains(out.String(), "│ changes") || strings.Contains(out.String(), "^") {
+		t.Errorf("color output should not have a marker column:\n%s", out.String())
+	}
+	for _, want := range []string{"\\" + removedCode + "/", "\\" + addedCode + "|", "Width changes: none"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output does not contain %q:\n%q", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := Write(&out, nil, Options{}); err != nil || out.String() != "No glyph differences.\n" {
+		t.Errorf("Write(nil) = %q, %v", out.String(), err)
+	}
+}
@@ -0,0 +1,504 @@
+// Package lint checks banner files for formatting problems and normalizes them.
+//
+// The linter understands the project's txt banner format: 95 glyphs for the
+// printable ASCII characters (32-126), each introduced by a blank separator line
+// and made of rows of equal width, optionally followed by sections tagged with
+// code points (see parser.ParseCodePointTag), after an optional metadata header
+// (see parser.Metadata). Unlike the parser, which stops at
+// the first problem, the linter reports every problem with the line number and
+// the character whose glyph is affected.
+//
+// Responsibilities of this package:
+//   - Detect separator, row width, whitespace, encoding and line count problems
+//   - Classify problems as errors or fixable warnings
+//   - Rewrite banner files with normalized row widths and line endings
+package lint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"ascii-art-fs/internal/parser"
+)
+
+const (
+	firstPrintable rune = 32 // ASCII 32 (space), the first glyph in a banner file
+	totalChars          = 95
+	defaultHeight       = 8 // glyph height assumed when the line count does not reveal it
+)
+
+// Severity classifies a diagnostic.
+type Severity int
+
+const (
+	// Warning marks a problem the parser tolerates or that Fix can repair.
+	Warning Severity = iota
+	// Error marks a problem that makes the banner unusable or misaligned.
+	Error
+)
+
+// String returns the lowercase name of the severity.
+func (s Severity) String() string {
+	if s == Error {
+		return "error"
+	}
+	return "warning"
+}
+
+// Diagnostic describes one problem found in a banner file.
+//
+// Line is 1-based, or 0 for problems not tied to a line. Rune is the character
+// whose glyph contains the line; it is -1 for lines that do not belong to any glyph.
+type Diagnostic struct {
+	Path     string
+	Line     int
+	Rune     rune
+	Severity Severity
+	Message  string
+}
+
+// String formats the diagnostic as "path:line: severity: glyph 'c': message",
+// leaving out the line when it is 0.
+func (d Diagnostic) String() string {
+	location := d.Path
+	if d.Line > 0 {
+		location = fmt.Sprintf("%s:%d", d.Path, d.Line)
+	}
+	if d.Rune < 0 {
+		return fmt.Sprintf("%s: %s: %s", location, d.Severity, d.Message)
+	}
+	return fmt.Sprintf("%s: %s: glyph %q: %s", location, d.Severity, d.Rune, d.Message)
+}
+
+// HasErrors reports whether any diagnostic has Error severity.
+func HasErrors(diags []Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == Error {
+			return true
+		}
+	}
+	return false
+}
+
+// layout describes how the lines of a banner file map to glyphs.
+type layout struct {
+	height int
+}
+
+// glyphOf returns the character and row (0 for the separator) of a 0-based line index.
+func (l layout) glyphOf(idx int) (rune, int) {
+	return firstPrintable + rune(idx/(l.height+1)), idx % (l.height + 1)
+}
+
+// expectedLines returns the line count of a complete banner.
+func (l layout) expectedLines() int {
+	return totalChars * (l.height + 1)
+}
+
+// Check lints the content of a banner file.
+//
+// Parameters:
+//   - path: The file name used in diagnostics.
+//   - data: The raw file content.
+//
+// Returns:
+//   - Every problem found, ordered by line number.
+func Check(path string, data []byte) []Diagnostic {
+	c := checker{path: path}
+	c.check(data)
+	sort.SliceStable(c.diags, func(i, j int) bool {
+		return c.diags[i].Line < c.diags[j].Line
+	})
+	return c.diags
+}
+
+// checker accumulates diagnostics for one file.
+//
+// offset is the number of metadata header lines before the glyphs; the checks
+// work on the lines after the header and report adds the offset back.
+type checker struct {
+	path   string
+	offset int
+	diags  []Diagnostic
+}
+
+// report records a diagnostic.
+func (c *checker) report(line int, r rune, severity Severity, format string, args ...any) {
+	c.diags = append(c.diags, Diagnostic{
+		Path:     c.path,
+		Line:     line + c.offset,
+		Rune:     r,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// check runs every check over the file content.
+func (c *checker) check(data []byte) {
+	if len(data) == 0 {
+		c.report(1, -1, Error, "empty banner file")
+		return
+	}
+
+	all := splitLines(data)
+	meta, n, err := parser.ParseFrontMatter(all)
+	if err != nil {
+		var perr *parser.ParseError
+		if errors.As(err, &perr) {
+			c.report(perr.Line, -1, Error, "%s", perr.Reason)
+		} else {
+			c.report(0, -1, Error, "%s", err.Error())
+		}
+		return
+	}
+	if n > 0 {
+		all, c.offset = all[n:], n
+		if len(all) == 0 {
+			c.report(0, -1, Error, "banner has a metadata header but no glyphs")
+			return
+		}
+	}
+	ext := parser.ExtensionStart(trimCR(all))
+	lines := all[:ext]
+	l, ok := inferLayout(lines)
+	if !ok {
+		c.report(1, -1, Warning, "cannot infer glyph height from %d lines; assuming %d rows per glyph",
+			len(lines), l.height)
+	}
+	if meta.Height != 0 && ok && meta.Height != l.height {
+		c.report(1-c.offset, -1, Error, "metadata declares height %d, but the glyphs have %d rows", meta.Height, l.height)
+	}
+
+	for idx, line := range lines {
+		c.checkLine(l, idx, line)
+	}
+	for start := 0; start < len(lines) && start < l.expectedLines(); start += l.height + 1 {
+		end := start + l.height + 1
+		if end > len(lines) {
+			end = len(lines)
+		}
+		r, _ := l.glyphOf(start)
+		c.checkRowWidths(r, start, lines[start+1:end])
+	}
+	c.checkLineCount(l, lines)
+	if ext < len(all) {
+		c.checkExtension(l, all, ext)
+	}
+}
+
+// checkLine runs the checks that apply to a single line of the ASCII glyphs.
+func (c *checker) checkLine(l layout, idx int, line string) {
+	lineNo := idx + 1
+	r, row := l.glyphOf(idx)
+	if idx >= l.expectedLines() {
+		r = -1
+	}
+
+	line = c.checkText(lineNo, r, line, true)
+	if row != 0 || r < 0 {
+		return
+	}
+	c.checkSeparator(lineNo, r, line)
+}
+
+// checkText reports line ending, tab and, if asciiOnly is set, non-ASCII
+// problems in a line and returns the line without its CR.
+func (c *checker) checkText(lineNo int, r rune, line string, asciiOnly bool) string {
+	if strings.HasSuffix(line, "\r") {
+		c.report(lineNo, r, Warning, "CRLF line ending")
+		line = strings.TrimSuffix(line, "\r")
+	}
+	if col := strings.IndexByte(line, '\t'); col >= 0 {
+		c.report(lineNo, r, Error, "tab character at column %d", col+1)
+	}
+	if !asciiOnly {
+		return line
+	}
+	for col := 0; col < len(line); col++ {
+		if line[col] >= 0x80 {
+			c.report(lineNo, r, Error, "non-ASCII byte 0x%02X at column %d", line[col], col+1)
+			break
+		}
+	}
+	return line
+}
+
+// checkSeparator reports a separator line that is not empty.
+func (c *checker) checkSeparator(lineNo int, r rune, line string) {
+	switch {
+	case strings.TrimSpace(line) != "":
+		c.report(lineNo, r, Error, "separator line is not empty: %q", line)
+	case line != "":
+		c.report(lineNo, r, Warning, "separator line contains whitespace")
+	}
+}
+
+// checkRowWidths reports rows whose width differs from the glyph's width.
+//
+// The glyph width is the most common row width. Rows that only differ in the
+// amount of trailing whitespace are reported as trailing-whitespace drift;
+// rows whose visible content does not fit the glyph width are errors.
+//
+// Parameters:
+//   - r: The character of the glyph, or -1 if unknown.
+//   - start: The 0-based index of the glyph's separator line.
+//   - rows: The glyph rows.
+func (c *checker) checkRowWidths(r rune, start int, rows []string) {
+	if len(rows) == 0 {
+		return
+	}
+	width := commonWidth(rows)
+
+	for i, row := range rows {
+		row = strings.TrimSuffix(row, "\r")
+		if columns(row) == width {
+			continue
+		}
+		lineNo := start + i + 2
+		if columns(strings.TrimRight(row, " ")) <= width {
+			c.report(lineNo, r, Warning, "trailing whitespace drift: row is %d columns wide, glyph is %d",
+				columns(row), width)
+			continue
+		}
+		c.report(lineNo, r, Error, "inconsistent row width: row is %d columns wide, glyph is %d",
+			columns(row), width)
+	}
+}
+
+// checkExtension checks the tagged sections that follow the ASCII glyphs.
+//
+// Every block starts with a code point tag or a blank separator that continues
+// the current range; the block's rows get the same checks as the ASCII glyphs.
+//
+// Parameters:
+//   - l: The layout inferred from the ASCII glyphs.
+//   - lines: All lines of the file.
+//   - start: The index of the first tag line.
+func (c *checker) checkExtension(l layout, lines []string, start int) {
+	next, last := rune(0), rune(-1)
+	for i := start; i < len(lines); i += l.height + 1 {
+		header := c.checkText(i+1, -1, lines[i], false)
+		r := rune(-1)
+		first, rangeEnd, isTag, err := parser.ParseCodePointTag(header)
+		switch {
+		case err != nil:
+			c.report(i+1, -1, Error, "%v", err)
+			next, last = 0, -1
+		case isTag:
+			if next <= last {
+				c.report(i+1, -1, Error, "range ends early, missing glyphs U+%04X..U+%04X", next, last)
+			}
+			r, next, last = first, first+1, rangeEnd
+		case next > last:
+			c.report(i+1, -1, Error, "glyph after the end of the tagged range; start a new range with a U+XXXX tag")
+		default:
+			r = next
+			next++
+			c.checkSeparator(i+1, r, header)
+		}
+
+		rows := lines[i+1 : min(i+l.height+1, len(lines))]
+		for j, row := range rows {
+			c.checkText(i+j+2, r, row, true)
+		}
+		if len(rows) < l.height {
+			c.report(len(lines), r, Error, "incomplete glyph: got %d of %d rows", len(rows), l.height)
+			return
+		}
+		c.checkRowWidths(r, i, rows)
+	}
+	if next <= last {
+		c.report(len(lines), -1, Error, "range ends early, missing glyphs U+%04X..U+%04X", next, last)
+	}
+}
+
+// checkLineCount reports missing glyph lines and lines after the last glyph.
+func (c *checker) checkLineCount(l layout, lines []string) {
+	expected := l.expectedLines()
+	if len(lines) < expected {
+		r, row := l.glyphOf(len(lines))
+		c.report(len(lines), -1, Error, "banner ends early: %d of %d lines, missing %d (glyph %q is incomplete at row %d)",
+			len(lines), expected, expected-len(lines), r, row)
+		return
+	}
+
+	for idx := expected; idx < len(lines); idx++ {
+		if strings.TrimSpace(lines[idx]) == "" {
+			c.report(idx+1, -1, Warning, "extra blank line after the last glyph")
+			continue
+		}
+		c.report(idx+1, -1, Error, "extra line after the last glyph: %q", lines[idx])
+	}
+}
+
+// Fix normalizes a banner file.
+//
+// A metadata header is kept as is apart from its line endings. Line endings
+// become LF, whitespace-only separators become empty, every row of
+// a glyph is padded with spaces to the widest visible row (trailing whitespace
+// beyond it is removed), blank lines after the last glyph are dropped and the
+// file ends with a single newline. Problems that cannot be repaired
+// automatically, such as tabs or missing glyphs, are left in place.
+//
+// Parameters:
+//   - data: The raw file content.
+//
+// Returns:
+//   - The normalized file content.
+func Fix(data []byte) []byte {
+	if len(data) == 0 {
+		return data
+	}
+
+	lines := trimCR(splitLines(data))
+	_, n, err := parser.ParseFrontMatter(lines)
+	if err != nil {
+		n = 0
+	}
+	header, lines := lines[:n:n], lines[n:]
+	ext := parser.ExtensionStart(lines)
+	l, _ := inferLayout(lines[:ext])
+	expected := l.expectedLines()
+	if ext < len(lines) {
+		// Tagged sections follow: keep whole blocks and normalize every one of them.
+		for (len(lines)-ext)%(l.height+1) != 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+			lines = lines[:len(lines)-1]
+		}
+		expected = len(lines)
+	}
+
+	for len(lines) > expected && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	for start := 0; start < len(lines) && start < expected; start += l.height + 1 {
+		if strings.TrimSpace(lines[start]) == "" {
+			lines[start] = ""
+		}
+		end := start + l.height + 1
+		if end > len(lines) {
+			end = len(lines)
+		}
+		normalizeRows(lines[start+1 : end])
+	}
+
+	var buf bytes.Buffer
+	for _, line := range append(header, lines...) {
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+// normalizeRows pads the rows of one glyph in place to a common width.
+//
+// The target width is the larger of the most common row width and the widest
+// visible content, so that rows which were merely padded inconsistently keep
+// the glyph's intended width.
+func normalizeRows(rows []string) {
+	if len(rows) == 0 {
+		return
+	}
+	width := commonWidth(rows)
+	for _, row := range rows {
+		if visible := columns(strings.TrimRight(row, " ")); visible > width {
+			width = visible
+		}
+	}
+	for i, row := range rows {
+		row = strings.TrimRight(row, " ")
+		rows[i] = row + strings.Repeat(" ", width-columns(row))
+	}
+}
+
+// columns returns the width of a row in characters, so that rows drawn with
+// multi-byte ink such as '█' are measured like the renderer draws them.
+func columns(row string) int {
+	return utf8.RuneCountInString(row)
+}
+
+// commonWidth returns the most frequent row width, preferring the narrower one on ties.
+func commonWidth(rows []string) int {
+	counts := make(map[int]int)
+	best, bestCount := 0, 0
+	for _, row := range rows {
+		w := columns(strings.TrimSuffix(row, "\r"))
+		counts[w]++
+		if counts[w] > bestCount || (counts[w] == bestCount && w < best) {
+			best, bestCount = w, counts[w]
+		}
+	}
+	return best
+}
+
+// inferLayout determines the glyph height of a banner file.
+//
+// The height normally follows from the line count; trailing blank lines are
+// ignored for this. When the count does not fit, the height whose separator
+// lines are all blank and whose expected line count is closest to the actual
+// one is used. When no height fits, the default height is assumed and ok is false.
+func inferLayout(lines []string) (l layout, ok bool) {
+	for n := len(lines); n > 0; n-- {
+		if n%totalChars == 0 && n/totalChars >= 2 {
+			return layout{height: n/totalChars - 1}, true
+		}
+		if strings.TrimSpace(lines[n-1]) != "" {
+			break
+		}
+	}
+
+	best := 0
+	for h := 1; h <= len(lines)/totalChars; h++ {
+		if !separatorsBlank(lines, h) {
+			continue
+		}
+		if best == 0 || lineDistance(lines, h) < lineDistance(lines, best) {
+			best = h
+		}
+	}
+	if best == 0 {
+		return layout{height: defaultHeight}, false
+	}
+	return layout{height: best}, true
+}
+
+// separatorsBlank reports whether every separator line for glyph height h that
+// is present in lines is blank.
+func separatorsBlank(lines []string, h int) bool {
+	for idx := 0; idx < len(lines) && idx < totalChars*(h+1); idx += h + 1 {
+		if strings.TrimSpace(lines[idx]) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// trimCR returns a copy of lines without their trailing carriage returns.
+func trimCR(lines []string) []string {
+	trimmed := make([]string, len(lines))
+	for i, line := range lines {
+		trimmed[i] = strings.TrimSuffix(line, "\r")
+	}
+	return trimmed
+}
+
+// lineDistance returns how many lines a banner with glyph height h would need
+// to gain or lose to match the expected line count.
+func lineDistance(lines []string, h int) int {
+	d := layout{height: h}.expectedLines() - len(lines)
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// splitLines splits file content on LF, keeping any CR so that CRLF endings can
+// be reported. A final line terminator does not produce an extra empty line.
+func splitLines(data []byte) []string {
+	text := strings.TrimSuffix(string(data), "\n")
+	return strings.Split(text, "\n")
+}
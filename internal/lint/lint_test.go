@@ -0,0 +1,317 @@
+package lint
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// buildBanner returns the lines of a clean banner file with 3-row glyphs, 3 columns wide.
+func buildBanner() []string {
+	var lines []string
+	for ch := 0; ch < totalChars; ch++ {
+		lines = append(lines, "", "/-\\", "| |", "\\_/")
+	}
+	return lines
+}
+
+func join(lines []string) []byte {
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
+
+func TestCheckCleanBanners(t *testing.T) {
+	if diags := Check("mini.txt", join(buildBanner())); len(diags) != 0 {
+		t.Errorf("clean banner reported %v", diags)
+	}
+
+	for _, name := range []string{"standard.txt", "shadow.txt", "thinkertoy.txt"} {
+		data, err := os.ReadFile("../../cmd/ascii-art/testdata/" + name)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		if diags := Check(name, data); len(diags) != 0 {
+			t.Errorf("%s reported %v", name, diags)
+		}
+	}
+}
+
+func TestCheckDiagnostics(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutate   func([]string) []string
+		line     int
+		r        rune
+		severity Severity
+		contains string
+	}{
+		{
+			name:     "non-blank separator",
+			mutate:   func(l []string) []string { l[4] = "x"; return l },
+			line:     5,
+			r:        '!',
+			severity: Error,
+			contains: "separator line is not empty",
+		},
+		{
+			name:     "whitespace separator",
+			mutate:   func(l []string) []string { l[8] = "  "; return l },
+			line:     9,
+			r:        '"',
+			severity: Warning,
+			contains: "separator line contains whitespace",
+		},
+		{
+			name:     "trailing whitespace drift",
+			mutate:   func(l []string) []string { l[133] += " "; return l },
+			line:     134,
+			r:        'A',
+			severity: Warning,
+			contains: "trailing whitespace drift",
+		},
+		{
+			name:     "inconsistent row width",
+			mutate:   func(l []string) []string { l[134] = "|  |"; return l },
+			line:     135,
+			r:        'A',
+			severity: Error,
+			contains: "inconsistent row width",
+		},
+		{
+			name:     "tab",
+			mutate:   func(l []string) []string { l[5] = "/\t\\"; return l },
+			line:     6,
+			r:        '!',
+			severity: Error,
+			contains: "tab character at column 2",
+		},
+		{
+			name:     "non-ASCII byte",
+			mutate:   func(l []string) []string { l[1] = "/é"; return l },
+			line:     2,
+			r:        ' ',
+			severity: Error,
+			contains: "non-ASCII byte 0xC3 at column 2",
+		},
+		{
+			name:     "CRLF",
+			mutate:   func(l []string) []string { l[2] += "\r"; return l },
+			line:     3,
+			r:        ' ',
+			severity: Warning,
+			contains: "CRLF line ending",
+		},
+		{
+			name:     "extra blank line",
+			mutate:   func(l []string) []string { return append(l, "") },
+			line:     381,
+			r:        -1,
+			severity: Warning,
+			contains: "extra blank line",
+		},
+		{
+			name:     "extra content line",
+			mutate:   func(l []string) []string { return append(l, "", "oops") },
+			line:     382,
+			r:        -1,
+			severity: Error,
+			contains: "extra line after the last glyph",
+		},
+		{
+			name:     "missing lines",
+			mutate:   func(l []string) []string { return l[:len(l)-2] },
+			line:     378,
+			r:        -1,
+			severity: Error,
+			contains: "glyph '~' is incomplete at row 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := Check("mini.txt", join(tt.mutate(buildBanner())))
+			for _, d := range diags {
+				if d.Line == tt.line && d.Rune == tt.r && d.Severity == tt.severity &&
+					strings.Contains(d.Message, tt.contains) {
+					return
+				}
+			}
+			t.Errorf("expected %s at line %d (glyph %q) containing %q, got %v",
+				tt.severity, tt.line, tt.r, tt.contains, diags)
+		})
+	}
+}
+
+func TestCheckEmptyFile(t *testing.T) {
+	diags := Check("empty.txt", nil)
+	if len(diags) != 1 || !HasErrors(diags) {
+		t.Errorf("Check(empty) = %v, want one error", diags)
+	}
+}
+
+func TestDiagnosticString(t *testing.T) {
+	d := Diagnostic{Path: "a.txt", Line: 12, Rune: 'A', Severity: Error, Message: "bad"}
+	if got, want := d.String(), "a.txt:12: error: glyph 'A': bad"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	d.Rune = -1
+	if got, want := d.String(), "a.txt:12: error: bad"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	d.Line = 0
+	if got, want := d.String(), "a.txt: error: bad"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestFix(t *testing.T) {
+	lines := buildBanner()
+	lines[0] = "   "
+	lines[1] += "\r"
+	lines[133] = "/-"
+	lines[134] += "  "
+	lines = append(lines, "", "  ")
+	messy := []byte(strings.Join(lines, "\n"))
+
+	if len(Check("mini.txt", messy)) == 0 {
+		t.Fatal("messy banner should have diagnostics")
+	}
+
+	fixed := Fix(messy)
+	if diags := Check("mini.txt", fixed); len(diags) != 0 {
+		t.Errorf("fixed banner still reports %v", diags)
+	}
+	if got := strings.Split(string(fixed), "\n")[133]; got != "/- " {
+		t.Errorf("short row = %q, want it padded to the glyph width", got)
+	}
+	if again := Fix(fixed); string(again) != string(fixed) {
+		t.Error("Fix should be idempotent")
+	}
+}
+
+func TestMultiByteInkWidths(t *testing.T) {
+	// Glyph '!' drawn with block ink, as written by import --ink=█.
+	lines := buildBanner()
+	lines[5], lines[6], lines[7] = "█   ", "█   ", "    "
+	for _, d := range Check("ink.txt", join(lines)) {
+		if strings.Contains(d.Message, "columns wide") {
+			t.Errorf("unexpected width diagnostic: %v", d)
+		}
+	}
+
+	// A row narrowed by its trailing spaces is padded back in characters.
+	lines[6] = "█"
+	fixed := strings.Split(string(Fix(join(lines))), "\n")
+	if got := fixed[6]; got != "█   " {
+		t.Errorf("fixed row = %q, want %q", got, "█   ")
+	}
+}
+
+func TestFixLeavesUnfixableErrors(t *testing.T) {
+	lines := buildBanner()
+	lines[5] = "/\t\\"
+	fixed := Fix(join(lines))
+	if !HasErrors(Check("mini.txt", fixed)) {
+		t.Error("tab should still be reported after Fix")
+	}
+}
+
+func TestCheckTaggedSections(t *testing.T) {
+	lines := append(buildBanner(),
+		"U+0391..U+0392 Greek", "/A\\", "| |", "\\_/",
+		"", "/B\\", "| |", "\\_/",
+		"U+03A9", "", "", "",
+	)
+	if diags := Check("greek.txt", join(lines)); len(diags) != 0 {
+		t.Errorf("tagged sections reported %v", diags)
+	}
+	if fixed := Fix(join(lines)); string(fixed) != string(join(lines)) {
+		t.Error("Fix should leave a clean tagged banner unchanged")
+	}
+	crlf := []byte(strings.ReplaceAll(string(join(lines)), "\n", "\r\n"))
+	if HasErrors(Check("greek.txt", crlf)) {
+		t.Errorf("tagged sections with CRLF endings reported errors: %v", Check("greek.txt", crlf))
+	}
+	if fixed := Fix(crlf); string(fixed) != string(join(lines)) {
+		t.Error("Fix should turn CRLF endings of a tagged banner into LF")
+	}
+
+	tests := []struct {
+		name     string
+		mutate   func([]string) []string
+		line     int
+		r        rune
+		contains string
+	}{
+		{
+			name:     "malformed tag",
+			mutate:   func(l []string) []string { l[380] = "U+ZZZZ"; return l },
+			line:     381,
+			r:        -1,
+			contains: "invalid code point tag",
+		},
+		{
+			name:     "row width in tagged glyph",
+			mutate:   func(l []string) []string { l[386] = "| ||"; return l },
+			line:     387,
+			r:        'Β',
+			contains: "inconsistent row width",
+		},
+		{
+			name:     "glyph after range",
+			mutate:   func(l []string) []string { l[388] = ""; return l },
+			line:     389,
+			r:        -1,
+			contains: "glyph after the end of the tagged range",
+		},
+		{
+			name:     "range ends early",
+			mutate:   func(l []string) []string { l[384] = "U+0392"; return l },
+			line:     385,
+			r:        -1,
+			contains: "range ends early",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := Check("greek.txt", join(tt.mutate(append([]string(nil), lines...))))
+			for _, d := range diags {
+				if d.Line == tt.line && d.Rune == tt.r && d.Severity == Error && strings.Contains(d.Message, tt.contains) {
+					return
+				}
+			}
+			t.Errorf("expected error at line %d (glyph %q) containing %q, got %v", tt.line, tt.r, tt.contains, diags)
+		})
+	}
+}
+
+func TestCheckMetadataHeader(t *testing.T) {
+	header := []string{"---", "name: Mini", "height: 3", "---"}
+	if diags := Check("mini.txt", join(append(header, buildBanner()...))); len(diags) != 0 {
+		t.Errorf("banner with metadata reported %v", diags)
+	}
+
+	lines := append([]string{"---", "height: 4", "---"}, buildBanner()...)
+	lines[3] = "x"
+	diags := Check("mini.txt", join(lines))
+	if len(diags) != 2 || diags[0].Line != 1 || !strings.Contains(diags[0].Message, "declares height 4") ||
+		diags[1].Line != 4 || !strings.Contains(diags[1].Message, "separator line is not empty") {
+		t.Errorf("diagnostics = %v, want the height at line 1 and the separator at line 4", diags)
+	}
+
+	diags = Check("mini.txt", join(append([]string{"---", "name Mini"}, buildBanner()...)))
+	if len(diags) != 1 || diags[0].Line != 2 || !HasErrors(diags) {
+		t.Errorf("malformed header diagnostics = %v", diags)
+	}
+}
+
+func TestFixKeepsMetadataHeader(t *testing.T) {
+	header := []string{"---", "name:   Mini  ", "---"}
+	lines := append(append([]string(nil), header...), buildBanner()...)
+	lines[3] = "  "
+	fixed := strings.Split(string(Fix(join(lines))), "\n")
+	if !reflect.DeepEqual(fixed[:3], header) || fixed[3] != "" {
+		t.Errorf("fixed file starts with %q", fixed[:4])
+	}
+}
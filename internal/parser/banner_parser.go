@@ -1,8 +1,12 @@
 // Package parser provides functionality for loading and parsing ASCII art banner files.
 //
 // The parser reads banner files containing ASCII art representations for printable
-// characters (range 32-126). Each banner file follows a strict format: 8 lines per
-// character definition plus 1 separator line, totaling 855 lines for 95 characters.
+// characters (range 32-126). Each character definition is an empty separator line
+// followed by the glyph rows. The bundled banners use 8 rows per glyph, totaling
+// 855 lines for 95 characters, but any glyph height is accepted: it is inferred
+// from the line count and confirmed by checking every separator line. After the
+// 95 ASCII glyphs, glyphs for other characters may follow in sections tagged with
+// their code points (see ParseCodePointTag).
 //
 // Responsibilities of this package:
 //   - Read banner files from the provided filesystem
@@ -17,26 +21,66 @@ import (
 	"bytes"
 	"fmt"
 	"io/fs"
+	"strings"
+	"unicode/utf8"
 )
 
 const (
 	firstPrintable rune = 32  // ASCII 32 (space)
 	lastPrintable  rune = 126 // ASCII 126 (tilde)
 	totalChars          = 95
-	expectedLines       = 855 // 95 chars × 9 lines (8 glyph + 1 separator)
-	linesPerGlyph       = 8
-	linesPerChar        = 9 // 8 glyph + 1 separator
+	linesPerGlyph       = 8 // glyph height of the bundled banners
 )
 
 // Banner represents the ASCII-art data for all supported characters.
 type Banner map[rune][]string
 
+// Height returns the number of rows in each glyph of the banner.
+//
+// The height is taken from the glyph with the lowest code point so the result is
+// deterministic; banners built by this package always use a single height for
+// every glyph.
+//
+// Returns:
+//   - The glyph height, or 0 for an empty banner.
+func (b Banner) Height() int {
+	first, found := rune(0), false
+	for r := range b {
+		if !found || r < first {
+			first, found = r, true
+		}
+	}
+	if !found {
+		return 0
+	}
+	return len(b[first])
+}
+
+// Mode selects how forgiving the parser is about the text layout of a file.
+type Mode int
+
+const (
+	// Strict parses files exactly as they are. Line endings may be LF or CRLF.
+	Strict Mode = iota
+	// Lenient additionally strips a UTF-8 byte order mark, accepts lone CR line
+	// endings and ignores empty lines at the end of the file, as left behind by
+	// some editors.
+	Lenient
+)
+
+// utf8BOM is the byte order mark some editors put at the start of UTF-8 files.
+const utf8BOM = "\uFEFF"
+
 // LoadBanner reads a banner file from the provided filesystem and returns its parsed
 // representation as a Banner map.
 //
-// The function reads the specified banner file, validates its format (855 lines total),
-// and constructs a map associating each printable ASCII character (32-126) with its
-// 8-line ASCII art representation.
+// The function reads the specified banner file, infers and validates its glyph height
+// (855 lines total for the bundled 8-row banners), and constructs a map associating
+// each printable ASCII character (32-126), and every character of the tagged
+// extended sections, with its ASCII art representation. An optional metadata
+// header at the start of the file is checked against the glyphs and skipped;
+// LoadMetadata returns it. The file is parsed in Strict mode; see LoadWithMode
+// for Lenient parsing.
 //
 // Parameters:
 //   - fsys: The filesystem to read from (can be embed.FS, os.DirFS, or any fs.FS).
@@ -44,15 +88,36 @@ type Banner map[rune][]string
 //
 // Returns:
 //   - A Banner map containing all character definitions.
-//   - An error if the file cannot be read or the format is invalid.
+//   - An error if the file cannot be read, or a *ParseError if the format is invalid.
 func LoadBanner(fsys fs.FS, path string) (Banner, error) {
-	lines, err := readLines(fsys, path)
+	return loadBanner(fsys, path, Strict)
+}
+
+// loadBanner reads and parses a banner file in the given mode.
+//
+// Parameters:
+//   - fsys: The filesystem to read from.
+//   - path: The file path within the filesystem.
+//   - mode: The parsing mode.
+//
+// Returns:
+//   - A Banner map containing all character definitions.
+//   - An error if the file cannot be read, or a *ParseError if the format is invalid.
+func loadBanner(fsys fs.FS, path string, mode Mode) (Banner, error) {
+	lines, err := readLines(fsys, path, mode)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read banner file %q: %w", path, err)
 	}
-	banner, err := buildBanner(lines)
+	meta, offset, err := ParseFrontMatter(lines)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse banner %q: %w", path, err)
+		return nil, withPath(err, path)
+	}
+	banner, err := buildBanner(lines[offset:])
+	if err != nil {
+		return nil, withPath(shiftLines(err, offset), path)
+	}
+	if _, err := meta.check(banner, 1, false); err != nil {
+		return nil, withPath(err, path)
 	}
 	return banner, nil
 }
@@ -61,20 +126,27 @@ func LoadBanner(fsys fs.FS, path string) (Banner, error) {
 //
 // The function uses fs.ReadFile to read the file content, then scans it line by line.
 // This approach works with both embedded filesystems and disk-based filesystems.
-// It handles both reading errors and scanner errors appropriately.
+// It handles both reading errors and scanner errors appropriately. In Lenient
+// mode the content is normalized first, as described for Lenient.
 //
 // Parameters:
 //   - fsys: The filesystem to read from (can be embed.FS, os.DirFS, or any fs.FS).
 //   - path: The file path within the filesystem.
+//   - mode: The parsing mode.
 //
 // Returns:
 //   - A slice containing all lines from the file.
 //   - An error if the file cannot be opened or read.
-func readLines(fsys fs.FS, path string) ([]string, error) {
+func readLines(fsys fs.FS, path string, mode Mode) ([]string, error) {
 	data, err := fs.ReadFile(fsys, path)
 	if err != nil {
 		return nil, err
 	}
+	if mode == Lenient {
+		data = bytes.TrimPrefix(data, []byte(utf8BOM))
+		data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+		data = bytes.ReplaceAll(data, []byte("\r"), []byte("\n"))
+	}
 
 	scanner := bufio.NewScanner(bytes.NewReader(data))
 	var lines []string
@@ -85,58 +157,85 @@ func readLines(fsys fs.FS, path string) ([]string, error) {
 	if err := scanner.Err(); err != nil {
 		return lines, err
 	}
+	if mode == Lenient {
+		for len(lines) > 0 && lines[len(lines)-1] == "" {
+			lines = lines[:len(lines)-1]
+		}
+	}
 	return lines, nil
 }
 
 // buildBanner constructs a Banner map from the raw lines read from a banner file.
 //
-// It validates the format (855 total lines, 95 characters with 8 lines each plus
-// 1 separator) and creates a mapping from each printable ASCII character (32-126)
-// to its 8-line ASCII art representation.
+// It infers the glyph height from the lines holding the 95 printable ASCII
+// characters (each made of one separator line plus the glyph rows), checks that
+// every separator line is blank, and creates a mapping from each printable ASCII
+// character (32-126) to its ASCII art representation. Tagged sections after the
+// ASCII glyphs add the characters they name.
 //
 // Parameters:
 //   - lines: The raw lines from a banner file.
 //
 // Returns:
 //   - A Banner map containing all character definitions.
-//   - An error if the format is invalid or incomplete.
+//   - A *ParseError if the format is invalid or incomplete.
 func buildBanner(lines []string) (Banner, error) {
 	if len(lines) == 0 {
-		return nil, fmt.Errorf("empty banner file")
+		return nil, parseError(0, -1, ErrEmptyFile, "empty banner file")
 	}
-	if len(lines) != expectedLines {
-		return nil, fmt.Errorf("invalid format: expected %d lines, got %d",
-			expectedLines, len(lines))
+	height, err := inferGlyphHeight(lines)
+	if err != nil {
+		return nil, err
 	}
+	return buildGlyphs(lines, height, false)
+}
 
-	banner := make(Banner)
-	runeCode := firstPrintable
-	i := 1
-
-	for i+linesPerGlyph <= len(lines) && runeCode <= lastPrintable {
-		block := lines[i : i+linesPerGlyph]
-		banner[runeCode] = block
-		runeCode++
-		i += linesPerChar
+// inferGlyphHeight determines the glyph height of a banner file from its lines.
+//
+// The printable ASCII section of a banner with glyphs of height h has exactly
+// totalChars*(h+1) lines, and every (h+1)-th line starting from the first is a
+// blank separator. The section ends at the first code point tag found at such a
+// boundary, or at the end of the file.
+//
+// Parameters:
+//   - lines: The raw lines from a banner file.
+//
+// Returns:
+//   - The glyph height.
+//   - A *ParseError if the line count does not match any height or a separator is not blank.
+func inferGlyphHeight(lines []string) (int, error) {
+	base := lines[:ExtensionStart(lines)]
+	if len(base)%totalChars != 0 || len(base)/totalChars < 2 {
+		return 0, parseError(0, -1, ErrLineCount, "invalid format: expected %d × (height+1) lines (%d for %d-row glyphs), got %d",
+			totalChars, totalChars*(linesPerGlyph+1), linesPerGlyph, len(base))
 	}
 
-	if len(banner) != totalChars {
-		return nil, fmt.Errorf("incomplete banner: got %d chars, expected %d",
-			len(banner), totalChars)
+	linesPerChar := len(base) / totalChars
+	for i := 0; i < len(base); i += linesPerChar {
+		if strings.TrimSpace(base[i]) != "" {
+			return 0, parseError(i+1, firstPrintable+rune(i/linesPerChar), ErrSeparator,
+				"separator line should be blank for glyph height %d", linesPerChar-1)
+		}
 	}
-	return banner, nil
+	return linesPerChar - 1, nil
 }
 
 // CharWidths returns the column width of each character in text based on the
-// provided Banner glyph data. Each width corresponds to len(glyph[0]) for the
-// character's ASCII art representation. Unknown characters get width 0.
+// provided Banner glyph data. Each width is the number of characters in the
+// first row of the character's ASCII art representation, so glyphs drawn with
+// multi-byte characters such as '█' count one column per character. Unknown
+// characters get width 0.
+//
+// The slice is indexed by byte offset in text, like the match positions used by
+// the coloring package: a multi-byte character such as 'Ω' has its width at the
+// offset of its first byte and 0 for its remaining bytes.
 //
 // Parameters:
 //   - text: The input string whose character widths are needed.
 //   - banner: The loaded Banner map containing glyph data.
 //
 // Returns:
-//   - A slice of integers with one width per character in text.
+//   - A slice of integers with one width per byte of text.
 func CharWidths(text string, banner Banner) []int {
 	widths := make([]int, len(text))
 	for i, char := range text {
@@ -144,7 +243,36 @@ func CharWidths(text string, banner Banner) []int {
 		if glyph == nil {
 			continue
 		}
-		widths[i] = len(glyph[0])
+		widths[i] = utf8.RuneCountInString(glyph[0])
+	}
+	return widths
+}
+
+// CharWidthsWithSpacing returns the column width of each character in text like
+// CharWidths, with the letter spacing of the renderer added: spacing columns
+// follow every character that has another character after it. A negative
+// spacing removes columns, but never makes a width negative. The renderer only
+// overlaps glyphs where they are blank, so with a negative spacing the widths
+// are the narrowest the text can be drawn.
+//
+// Parameters:
+//   - text: The input string whose character widths are needed.
+//   - banner: The loaded Banner map containing glyph data.
+//   - spacing: The columns added between two glyphs.
+//
+// Returns:
+//   - A slice of integers with one width per byte of text.
+func CharWidthsWithSpacing(text string, banner Banner, spacing int) []int {
+	widths := CharWidths(text, banner)
+	prev := -1
+	for i, char := range text {
+		if banner[char] == nil {
+			continue
+		}
+		if prev >= 0 {
+			widths[prev] = max(0, widths[prev]+spacing)
+		}
+		prev = i
 	}
 	return widths
 }
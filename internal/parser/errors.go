@@ -0,0 +1,113 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sentinel errors classifying why a banner file could not be parsed. A
+// *ParseError wraps one of them, so callers can test for a category with
+// errors.Is. Errors reading the file are returned unchanged and wrap the
+// underlying fs error instead, so errors.Is(err, fs.ErrNotExist) reports a
+// missing file.
+var (
+	// ErrEmptyFile reports a banner file without any lines.
+	ErrEmptyFile = errors.New("empty banner file")
+	// ErrLineCount reports a line count that does not form whole glyphs.
+	ErrLineCount = errors.New("wrong line count")
+	// ErrSeparator reports a separator line that is neither blank nor a code point tag.
+	ErrSeparator = errors.New("invalid separator line")
+	// ErrIncomplete reports a banner or range that ends before all its glyphs are defined.
+	ErrIncomplete = errors.New("incomplete banner")
+	// ErrCodePointTag reports a malformed code point tag.
+	ErrCodePointTag = errors.New("invalid code point tag")
+	// ErrDuplicateGlyph reports a character that is defined twice.
+	ErrDuplicateGlyph = errors.New("duplicate glyph")
+	// ErrMalformedGlyph reports a glyph with missing or misplaced rows.
+	ErrMalformedGlyph = errors.New("malformed glyph")
+	// ErrFIGletHeader reports an invalid FIGlet header line.
+	ErrFIGletHeader = errors.New("invalid FIGlet header")
+	// ErrJSON reports a JSON banner document that cannot be decoded.
+	ErrJSON = errors.New("invalid JSON banner")
+	// ErrMetadata reports a malformed metadata header or metadata that
+	// contradicts the glyphs.
+	ErrMetadata = errors.New("invalid banner metadata")
+)
+
+// ParseError describes why a banner file could not be parsed and where.
+type ParseError struct {
+	// Path is the file path within the filesystem the banner was loaded from.
+	Path string
+	// Line is the 1-based line number of the problem, or 0 if it concerns the whole file.
+	Line int
+	// Rune is the character whose glyph is affected, or -1 if none is.
+	Rune rune
+	// Reason is the human-readable description of the problem.
+	Reason string
+	// Err is the sentinel error classifying the problem, such as ErrLineCount.
+	Err error
+}
+
+// Error returns the message in the form
+// `failed to parse banner "path": line N: glyph 'c': reason`, leaving out the
+// parts that are unknown.
+func (e *ParseError) Error() string {
+	var sb strings.Builder
+	if e.Path != "" {
+		kind := "banner"
+		switch {
+		case IsFIGletPath(e.Path):
+			kind = "FIGlet font"
+		case IsJSONPath(e.Path):
+			kind = "JSON banner"
+		}
+		fmt.Fprintf(&sb, "failed to parse %s %q: ", kind, e.Path)
+	}
+	if e.Line > 0 {
+		fmt.Fprintf(&sb, "line %d: ", e.Line)
+	}
+	if e.Rune >= 0 {
+		fmt.Fprintf(&sb, "glyph %q: ", e.Rune)
+	}
+	sb.WriteString(e.Reason)
+	return sb.String()
+}
+
+// Unwrap returns the sentinel error, so that errors.Is matches the category.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// parseError creates a *ParseError without a path; the Load functions fill in
+// the path of the file being parsed with withPath.
+//
+// Parameters:
+//   - line: The 1-based line number, or 0 for the whole file.
+//   - r: The affected character, or -1.
+//   - sentinel: The error category.
+//   - format: The fmt format of the reason, followed by its arguments.
+//
+// Returns:
+//   - The parse error.
+func parseError(line int, r rune, sentinel error, format string, args ...any) *ParseError {
+	return &ParseError{Line: line, Rune: r, Reason: fmt.Sprintf(format, args...), Err: sentinel}
+}
+
+// withPath records the path of the file being parsed in the *ParseError
+// returned by one of the build functions.
+//
+// Parameters:
+//   - err: An error returned by one of the build functions.
+//   - path: The path of the file being parsed.
+//
+// Returns:
+//   - The *ParseError with its path set; any other error is wrapped in one.
+func withPath(err error, path string) *ParseError {
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		perr = &ParseError{Rune: -1, Reason: err.Error(), Err: err}
+	}
+	perr.Path = path
+	return perr
+}
@@ -0,0 +1,131 @@
+package parser
+
+import (
+	"errors"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestParseErrorFields(t *testing.T) {
+	good := buildBannerFile(2)
+	tests := []struct {
+		name     string
+		data     string
+		sentinel error
+		line     int
+		r        rune
+	}{
+		{"empty file", "", ErrEmptyFile, 0, -1},
+		{"line count", good + "\n", ErrLineCount, 0, -1},
+		{"separator", strings.Replace(good, "\n!!", "x\n!!", 1), ErrSeparator, 4, '!'},
+		{"duplicate glyph", good + "U+0041\nAA\nAA", ErrDuplicateGlyph, 286, 'A'},
+		{"range ends early", good + "U+0391..U+0392\nαα\nαα", ErrIncomplete, 288, -1},
+		{"bad tag", good + "U+XYZ\nαα\nαα", ErrCodePointTag, 286, -1},
+		{"incomplete glyph", good + "U+03B1\nαα", ErrMalformedGlyph, 286, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{"bad.txt": {Data: []byte(tt.data)}}
+			_, err := LoadBanner(fsys, "bad.txt")
+			if !errors.Is(err, tt.sentinel) {
+				t.Fatalf("error = %v, want it to match %v", err, tt.sentinel)
+			}
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("error = %T, want *ParseError", err)
+			}
+			if perr.Path != "bad.txt" || perr.Line != tt.line || perr.Rune != tt.r {
+				t.Errorf("got path %q, line %d, rune %d; want bad.txt, line %d, rune %d",
+					perr.Path, perr.Line, perr.Rune, tt.line, tt.r)
+			}
+		})
+	}
+}
+
+func TestParseErrorMessage(t *testing.T) {
+	tests := []struct {
+		err  *ParseError
+		want string
+	}{
+		{&ParseError{Path: "a.txt", Line: 7, Rune: '!', Reason: "bad", Err: ErrSeparator},
+			`failed to parse banner "a.txt": line 7: glyph '!': bad`},
+		{&ParseError{Path: "a.flf", Rune: -1, Reason: "bad", Err: ErrFIGletHeader},
+			`failed to parse FIGlet font "a.flf": bad`},
+		{&ParseError{Line: 3, Rune: -1, Reason: "bad", Err: ErrLineCount}, "line 3: bad"},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestLoadErrorsNotParseErrors(t *testing.T) {
+	_, err := Load(fstest.MapFS{}, "missing.txt")
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("error = %v, want fs.ErrNotExist", err)
+	}
+	var perr *ParseError
+	if errors.As(err, &perr) {
+		t.Error("a missing file should not be a *ParseError")
+	}
+}
+
+func TestFIGletParseErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		sentinel error
+		line     int
+	}{
+		{"bad header", "flf2a$ x 1 4 -1 0\n", ErrFIGletHeader, 1},
+		{"missing glyphs", "flf2a$ 2 1 4 -1 0\n!@\n!@@\n", ErrIncomplete, 0},
+		{"bad code tag", buildTestFont(strings.Repeat("d@\n", 14) + "oops\na@\na@@\n"), ErrCodePointTag, 208},
+	}
+	for _, tt := range tests {
+		fsys := fstest.MapFS{"bad.flf": {Data: []byte(tt.data)}}
+		_, err := Load(fsys, "bad.flf")
+		var perr *ParseError
+		if !errors.Is(err, tt.sentinel) || !errors.As(err, &perr) || perr.Line != tt.line {
+			t.Errorf("%s: error = %v, want %v at line %d", tt.name, err, tt.sentinel, tt.line)
+		}
+	}
+}
+
+func TestLoadWithModeLenient(t *testing.T) {
+	good := buildBannerFile(2)
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"byte order mark", utf8BOM + good},
+		{"CRLF line endings", strings.ReplaceAll(good, "\n", "\r\n")},
+		{"CR line endings", strings.ReplaceAll(good, "\n", "\r")},
+		{"trailing blank lines", good + "\n\n\n"},
+		{"everything", utf8BOM + strings.ReplaceAll(good, "\n", "\r\n") + "\r\n\r\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{"messy.txt": {Data: []byte(tt.data)}}
+			banner, err := LoadWithMode(fsys, "messy.txt", Lenient)
+			if err != nil {
+				t.Fatalf("Lenient load failed: %v", err)
+			}
+			if len(banner) != totalChars || banner['~'][1] != "~~" {
+				t.Errorf("got %d glyphs, '~' = %q", len(banner), banner['~'])
+			}
+		})
+	}
+
+	strict := []string{utf8BOM + good, good + "\n\n"}
+	for _, data := range strict {
+		fsys := fstest.MapFS{"messy.txt": {Data: []byte(data)}}
+		if _, err := LoadWithMode(fsys, "messy.txt", Strict); err == nil {
+			t.Errorf("Strict load of %q... should fail", data[:8])
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	codePointPrefix = "U+"
+	rangeSeparator  = ".."
+)
+
+// ParseCodePointTag parses a code point tag line from the extended banner format.
+//
+// After the 95 printable ASCII glyphs, a banner file may define more glyphs. Each
+// extra section starts with a tag line in place of the blank separator: either a
+// single code point ("U+03A9") or an inclusive range ("U+0391..U+03A9"),
+// optionally followed by whitespace and a comment. A single code point tags the
+// glyph that follows; a range tags that many consecutive glyphs, the first one
+// after the tag line and each further one after a blank separator.
+//
+// Parameters:
+//   - line: The line to parse.
+//
+// Returns:
+//   - The first and last code point of the tagged range (equal for a single code point).
+//   - Whether the line is a tag line, i.e. starts with "U+".
+//   - An error wrapping ErrCodePointTag if the line is a tag line but malformed.
+func ParseCodePointTag(line string) (first, last rune, ok bool, err error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || !strings.HasPrefix(fields[0], codePointPrefix) {
+		return 0, 0, false, nil
+	}
+
+	from, to, isRange := strings.Cut(fields[0], rangeSeparator)
+	if first, err = parseCodePoint(from); err != nil {
+		return 0, 0, true, fmt.Errorf("%w %q: %w", ErrCodePointTag, line, err)
+	}
+	last = first
+	if isRange {
+		if last, err = parseCodePoint(to); err != nil {
+			return 0, 0, true, fmt.Errorf("%w %q: %w", ErrCodePointTag, line, err)
+		}
+		if last < first {
+			return 0, 0, true, fmt.Errorf("%w %q: range ends before it starts", ErrCodePointTag, line)
+		}
+	}
+	return first, last, true, nil
+}
+
+// parseCodePoint parses one "U+XXXX" code point.
+//
+// Parameters:
+//   - s: The code point in U+ notation.
+//
+// Returns:
+//   - The code point.
+//   - An error if s is not a valid, non-control Unicode code point.
+func parseCodePoint(s string) (rune, error) {
+	digits, found := strings.CutPrefix(s, codePointPrefix)
+	if !found || digits == "" {
+		return 0, fmt.Errorf("expected %sXXXX, got %q", codePointPrefix, s)
+	}
+	value, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("expected hexadecimal digits in %q", s)
+	}
+	r := rune(value)
+	if !utf8.ValidRune(r) {
+		return 0, fmt.Errorf("%s is not a valid code point", s)
+	}
+	if unicode.IsControl(r) {
+		return 0, fmt.Errorf("%s is a control character", s)
+	}
+	return r, nil
+}
+
+// ExtensionStart returns the index of the first code point tag line that
+// follows a whole number of ASCII glyph blocks, or len(lines) when the banner
+// has no extended glyphs.
+//
+// Parameters:
+//   - lines: The lines from a banner file, without line endings.
+//
+// Returns:
+//   - The line index where the extended glyphs start.
+func ExtensionStart(lines []string) int {
+	for p := 2 * totalChars; p < len(lines); p += totalChars {
+		if _, _, ok, _ := ParseCodePointTag(lines[p]); ok {
+			return p
+		}
+	}
+	return len(lines)
+}
+
+// buildGlyphs walks the glyph blocks of a banner file with a known glyph height.
+//
+// Every block is a header line followed by height rows. A blank header assigns
+// the next code point of the current range, which starts as the printable ASCII
+// range 32-126; a tag header starts a new range. Glyphs in tagged ranges whose
+// rows are all empty strings are placeholders and are skipped, so that ranges
+// can span unassigned code points.
+//
+// In partial mode placeholders are skipped in every range, a range may end
+// early, and the file may start with a tag instead of the ASCII glyphs.
+//
+// Parameters:
+//   - lines: The raw lines from a banner file.
+//   - height: The glyph height.
+//   - partial: Whether the banner may define only some glyphs.
+//
+// Returns:
+//   - The Banner map.
+//   - A *ParseError naming the offending line if the layout is invalid.
+func buildGlyphs(lines []string, height int, partial bool) (Banner, error) {
+	linesPerChar := height + 1
+	if len(lines)%linesPerChar != 0 {
+		start := len(lines) - len(lines)%linesPerChar
+		return nil, parseError(start+1, -1, ErrMalformedGlyph, "incomplete glyph: got %d of %d rows",
+			len(lines)-start-1, height)
+	}
+
+	banner := make(Banner)
+	next, last := firstPrintable, lastPrintable
+	tagged := false
+
+	for i := 0; i < len(lines); i += linesPerChar {
+		first, end, isTag, err := ParseCodePointTag(lines[i])
+		switch {
+		case err != nil:
+			return nil, parseError(i+1, -1, ErrCodePointTag, "%v", err)
+		case isTag:
+			if next <= last && !partial {
+				return nil, incompleteRangeError(i+1, next, last, tagged)
+			}
+			next, last, tagged = first, end, true
+		case strings.TrimSpace(lines[i]) != "":
+			r := rune(-1)
+			if next <= last {
+				r = next
+			}
+			return nil, parseError(i+1, r, ErrSeparator, "separator line should be blank or a %sXXXX tag for glyph height %d",
+				codePointPrefix, height)
+		case next > last:
+			return nil, parseError(i+1, -1, ErrCodePointTag, "glyph after the range ending at %s; start a new range with a %sXXXX tag",
+				formatCodePoint(last), codePointPrefix)
+		}
+
+		block := lines[i+1 : i+linesPerChar]
+		if (!tagged && !partial) || !isPlaceholderGlyph(block) {
+			if _, dup := banner[next]; dup {
+				return nil, parseError(i+1, next, ErrDuplicateGlyph, "duplicate glyph for %s", formatCodePoint(next))
+			}
+			banner[next] = block
+		}
+		next++
+	}
+
+	if next <= last && !partial {
+		return nil, incompleteRangeError(len(lines), next, last, tagged)
+	}
+	return banner, nil
+}
+
+// incompleteRangeError reports a range that ends before all its glyphs are defined.
+func incompleteRangeError(line int, next, last rune, tagged bool) *ParseError {
+	if !tagged {
+		return parseError(0, -1, ErrIncomplete, "incomplete banner: got %d chars, expected %d",
+			next-firstPrintable, totalChars)
+	}
+	return parseError(line, -1, ErrIncomplete, "range ends early, missing glyphs %s..%s",
+		formatCodePoint(next), formatCodePoint(last))
+}
+
+// formatCodePoint formats a rune in U+ notation.
+func formatCodePoint(r rune) string {
+	return fmt.Sprintf("%s%04X", codePointPrefix, r)
+}
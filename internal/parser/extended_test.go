@@ -0,0 +1,132 @@
+package parser
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// withExtension appends tagged sections to the bundled standard banner.
+func withExtension(t *testing.T, sections ...string) []byte {
+	t.Helper()
+	data, err := os.ReadFile("../../cmd/ascii-art/testdata/standard.txt")
+	if err != nil {
+		t.Fatalf("failed to read standard.txt: %v", err)
+	}
+	return []byte(strings.TrimSuffix(string(data), "\n") + "\n" + strings.Join(sections, "\n"))
+}
+
+// glyphBlock returns 8 rows showing label, without the separator line.
+func glyphBlock(label string) string {
+	return strings.TrimSuffix(strings.Repeat(label+"\n", 8), "\n")
+}
+
+// emptyBlock returns 8 empty rows, a placeholder glyph.
+func emptyBlock() string {
+	return strings.Repeat("\n", 7)
+}
+
+func TestParseCodePointTag(t *testing.T) {
+	tests := []struct {
+		line        string
+		first, last rune
+		ok, wantErr bool
+	}{
+		{"U+03A9", 0x3A9, 0x3A9, true, false},
+		{"U+0391..U+0393  Greek capitals", 0x391, 0x393, true, false},
+		{"u+03A9", 0, 0, false, false},
+		{"", 0, 0, false, false},
+		{"  _  ", 0, 0, false, false},
+		{"U+", 0, 0, true, true},
+		{"U+XYZ", 0, 0, true, true},
+		{"U+0393..U+0391", 0, 0, true, true},
+		{"U+0391..0393", 0, 0, true, true},
+		{"U+D800", 0, 0, true, true},
+		{"U+0009", 0, 0, true, true},
+	}
+
+	for _, tt := range tests {
+		first, last, ok, err := ParseCodePointTag(tt.line)
+		if ok != tt.ok || (err != nil) != tt.wantErr {
+			t.Errorf("ParseCodePointTag(%q) ok = %v, err = %v; want ok %v, error %v", tt.line, ok, err, tt.ok, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && (first != tt.first || last != tt.last) {
+			t.Errorf("ParseCodePointTag(%q) = %U..%U, want %U..%U", tt.line, first, last, tt.first, tt.last)
+		}
+	}
+}
+
+func TestLoadBannerExtendedGlyphs(t *testing.T) {
+	data := withExtension(t,
+		"U+0391..U+0393 Alpha to Gamma", glyphBlock("Α"),
+		"", glyphBlock("Β"),
+		"", glyphBlock("Γ"),
+		"U+03A1..U+03A3", glyphBlock("Ρ"),
+		"", emptyBlock(),
+		"", glyphBlock("Σ"),
+		"U+00E9", glyphBlock("é"),
+	)
+	fsys := fstest.MapFS{"greek.txt": {Data: data}}
+
+	banner, err := LoadBanner(fsys, "greek.txt")
+	if err != nil {
+		t.Fatalf("LoadBanner failed: %v", err)
+	}
+	if len(banner) != totalChars+6 {
+		t.Errorf("got %d glyphs, want %d", len(banner), totalChars+6)
+	}
+	for r, label := range map[rune]string{'Α': "Α", 'Γ': "Γ", 'Σ': "Σ", 'é': "é"} {
+		if glyph := banner[r]; len(glyph) != 8 || glyph[0] != label {
+			t.Errorf("glyph for %q = %q", r, glyph)
+		}
+	}
+	if _, ok := banner[0x3A2]; ok {
+		t.Error("placeholder for unassigned U+03A2 should be skipped")
+	}
+	if banner['A'][0] == "" {
+		t.Error("ASCII glyphs should still be loaded")
+	}
+}
+
+func TestLoadBannerExtendedErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		sections []string
+		contains string
+	}{
+		{"range ends early", []string{"U+0391..U+0393", glyphBlock("Α")}, "range ends early"},
+		{"glyph after range", []string{"U+03A9", glyphBlock("Ω"), "", glyphBlock("?")}, "glyph after the range ending at U+03A9"},
+		{"malformed tag", []string{"U+03A9", glyphBlock("Ω"), "U+ZZ", glyphBlock("?")}, "invalid code point tag"},
+		{"non-tag header", []string{"U+03A9", glyphBlock("Ω"), "oops", glyphBlock("?")}, "blank or a U+XXXX tag"},
+		{"duplicate glyph", []string{"U+0041", glyphBlock("A")}, "duplicate glyph for U+0041"},
+		{"incomplete glyph", []string{"U+03A9", "Ω"}, "incomplete glyph"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{"bad.txt": {Data: withExtension(t, tt.sections...)}}
+			_, err := LoadBanner(fsys, "bad.txt")
+			if err == nil || !strings.Contains(err.Error(), tt.contains) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.contains)
+			}
+		})
+	}
+}
+
+func TestLoadPartialBannerOnlyExtended(t *testing.T) {
+	data := "U+03A9\nOO\nOO\nU+0391..U+0392\nAA\nAA\n\nBB\nBB"
+	fsys := fstest.MapFS{"greek.txt": {Data: []byte(data)}}
+
+	banner, warnings, err := LoadPartialBanner(fsys, "greek.txt")
+	if err != nil {
+		t.Fatalf("LoadPartialBanner failed: %v", err)
+	}
+	if len(banner) != 3 || banner.Height() != 2 || banner['Β'][0] != "BB" {
+		t.Errorf("got %q, want 3 two-row Greek glyphs", banner)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "got 0 chars") {
+		t.Errorf("warnings = %q, want a missing ASCII warning", warnings)
+	}
+}
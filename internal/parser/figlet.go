@@ -0,0 +1,333 @@
+package parser
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	figletSignature  = "flf2a"
+	figletExtension  = ".flf"
+	minHeaderParams  = 6 // signature+hardblank, height, baseline, max length, old layout, comment lines
+	printDirParamIdx = 6
+)
+
+// deutschRunes lists the seven German characters that follow the 95 required
+// ASCII glyphs in every FIGlet font, in the order mandated by the FIGfont spec.
+var deutschRunes = []rune{196, 214, 220, 228, 246, 252, 223}
+
+// FIGletHeader holds the parameters declared on the first line of a FIGlet font.
+type FIGletHeader struct {
+	Hardblank      rune
+	Height         int
+	Baseline       int
+	MaxLength      int
+	OldLayout      int
+	CommentLines   int
+	PrintDirection int
+}
+
+// FIGletFont is a parsed FIGlet (.flf) font.
+//
+// Glyphs keeps the hardblank characters exactly as they appear in the font file,
+// which is what layout code needs to honor them. Use Banner to obtain glyphs
+// suitable for direct rendering.
+type FIGletFont struct {
+	Header   FIGletHeader
+	Comments []string
+	Glyphs   Banner
+}
+
+// Banner returns the font glyphs with every hardblank replaced by a space,
+// ready to be passed to renderer.ASCII.
+//
+// Returns:
+//   - A new Banner map; the font itself is not modified.
+func (f *FIGletFont) Banner() Banner {
+	banner := make(Banner, len(f.Glyphs))
+	hardblank := string(f.Header.Hardblank)
+	for r, rows := range f.Glyphs {
+		out := make([]string, len(rows))
+		for i, row := range rows {
+			out[i] = strings.ReplaceAll(row, hardblank, " ")
+		}
+		banner[r] = out
+	}
+	return banner
+}
+
+// Load reads a banner from the provided filesystem, choosing the parser from
+// the file extension: ".flf" files are read as FIGlet fonts, ".json" files as
+// JSON banners, everything else as the project's 855-line banner format.
+//
+// Parameters:
+//   - fsys: The filesystem to read from.
+//   - name: The file path within the filesystem.
+//
+// Returns:
+//   - A Banner map containing all character definitions.
+//   - An error if the file cannot be read, or a *ParseError if the format is invalid.
+func Load(fsys fs.FS, name string) (Banner, error) {
+	return LoadWithMode(fsys, name, Strict)
+}
+
+// LoadWithMode is like Load but parses the file in the given mode.
+//
+// Parameters:
+//   - fsys: The filesystem to read from.
+//   - name: The file path within the filesystem.
+//   - mode: The parsing mode.
+//
+// Returns:
+//   - A Banner map containing all character definitions.
+//   - An error if the file cannot be read, or a *ParseError if the format is invalid.
+func LoadWithMode(fsys fs.FS, name string, mode Mode) (Banner, error) {
+	switch {
+	case IsFIGletPath(name):
+		font, err := loadFIGletFont(fsys, name, mode)
+		if err != nil {
+			return nil, err
+		}
+		return font.Banner(), nil
+	case IsJSONPath(name):
+		banner, _, err := loadJSON(fsys, name, mode, false)
+		return banner, err
+	}
+	return loadBanner(fsys, name, mode)
+}
+
+// IsFIGletPath reports whether name has the FIGlet font extension (".flf").
+func IsFIGletPath(name string) bool {
+	return strings.EqualFold(path.Ext(name), figletExtension)
+}
+
+// LoadFIGlet reads a FIGlet font and returns its glyphs as a Banner map with
+// hardblanks replaced by spaces.
+//
+// Parameters:
+//   - fsys: The filesystem to read from.
+//   - path: The file path within the filesystem (e.g., "fonts/small.flf").
+//
+// Returns:
+//   - A Banner map containing all character definitions.
+//   - An error if the file cannot be read, or a *ParseError if it is not a valid FIGlet font.
+func LoadFIGlet(fsys fs.FS, path string) (Banner, error) {
+	font, err := LoadFIGletFont(fsys, path)
+	if err != nil {
+		return nil, err
+	}
+	return font.Banner(), nil
+}
+
+// LoadFIGletFont reads and parses a FIGlet font file.
+//
+// The 95 printable ASCII glyphs are required. The seven German glyphs and any
+// code-tagged glyphs that follow them are optional; negative code points and
+// the reserved code -1 are skipped.
+//
+// Parameters:
+//   - fsys: The filesystem to read from.
+//   - path: The file path within the filesystem.
+//
+// Returns:
+//   - The parsed font including header, comments and raw glyphs.
+//   - An error if the file cannot be read, or a *ParseError if it is not a valid FIGlet font.
+func LoadFIGletFont(fsys fs.FS, path string) (*FIGletFont, error) {
+	return loadFIGletFont(fsys, path, Strict)
+}
+
+// loadFIGletFont reads and parses a FIGlet font file in the given mode.
+//
+// Parameters:
+//   - fsys: The filesystem to read from.
+//   - path: The file path within the filesystem.
+//   - mode: The parsing mode.
+//
+// Returns:
+//   - The parsed font.
+//   - An error if the file cannot be read, or a *ParseError if it is not a valid FIGlet font.
+func loadFIGletFont(fsys fs.FS, path string, mode Mode) (*FIGletFont, error) {
+	lines, err := readLines(fsys, path, mode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read FIGlet font %q: %w", path, err)
+	}
+	font, err := buildFIGletFont(lines, false)
+	if err != nil {
+		return nil, withPath(err, path)
+	}
+	return font, nil
+}
+
+// buildFIGletFont constructs a FIGletFont from the raw lines of a .flf file.
+//
+// In partial mode a font that ends before all 95 printable glyphs are defined is
+// accepted and contains only the glyphs that are present.
+//
+// Parameters:
+//   - lines: The raw lines from a FIGlet font file.
+//   - partial: Whether missing printable glyphs are allowed.
+//
+// Returns:
+//   - The parsed font.
+//   - A *ParseError if the header is malformed or a required glyph is missing.
+func buildFIGletFont(lines []string, partial bool) (*FIGletFont, error) {
+	if len(lines) == 0 {
+		return nil, parseError(0, -1, ErrEmptyFile, "empty FIGlet font file")
+	}
+	header, err := parseFIGletHeader(lines[0])
+	if err != nil {
+		return nil, parseError(1, -1, ErrFIGletHeader, "%v", err)
+	}
+
+	body := lines[1:]
+	// lineNo returns the 1-based line number of the first line of body.
+	lineNo := func() int { return len(lines) - len(body) + 1 }
+	if len(body) < header.CommentLines {
+		return nil, parseError(0, -1, ErrLineCount, "expected %d comment lines, got %d", header.CommentLines, len(body))
+	}
+	font := &FIGletFont{
+		Header:   header,
+		Comments: body[:header.CommentLines],
+		Glyphs:   make(Banner),
+	}
+	body = body[header.CommentLines:]
+
+	for r := firstPrintable; r <= lastPrintable; r++ {
+		if len(body) < header.Height {
+			if partial {
+				return font, nil
+			}
+			return nil, parseError(0, r, ErrIncomplete, "incomplete font: missing glyph (code %d)", r)
+		}
+		font.Glyphs[r] = readFIGletGlyph(body[:header.Height])
+		body = body[header.Height:]
+	}
+
+	for _, r := range deutschRunes {
+		if len(body) < header.Height {
+			return font, nil
+		}
+		// A German glyph of zero width marks a character the font does not define.
+		if glyph := readFIGletGlyph(body[:header.Height]); !isPlaceholderGlyph(glyph) {
+			font.Glyphs[r] = glyph
+		}
+		body = body[header.Height:]
+	}
+
+	for len(body) > 0 {
+		if strings.TrimSpace(body[0]) == "" {
+			body = body[1:]
+			continue
+		}
+		code, err := parseCodeTag(body[0])
+		if err != nil {
+			return nil, parseError(lineNo(), -1, ErrCodePointTag, "%v", err)
+		}
+		if len(body) < header.Height+1 {
+			return nil, parseError(lineNo(), -1, ErrMalformedGlyph, "incomplete glyph for code tag %q", body[0])
+		}
+		if code >= 0 {
+			font.Glyphs[code] = readFIGletGlyph(body[1 : header.Height+1])
+		}
+		body = body[header.Height+1:]
+	}
+
+	return font, nil
+}
+
+// parseFIGletHeader parses the "flf2a" header line of a FIGlet font.
+//
+// Parameters:
+//   - line: The first line of the font file.
+//
+// Returns:
+//   - The parsed header.
+//   - An error if the signature or any numeric parameter is invalid.
+func parseFIGletHeader(line string) (FIGletHeader, error) {
+	fields := strings.Fields(line)
+	if len(fields) < minHeaderParams || !strings.HasPrefix(fields[0], figletSignature) ||
+		len(fields[0]) <= len(figletSignature) {
+		return FIGletHeader{}, fmt.Errorf("invalid FIGlet header %q", line)
+	}
+
+	header := FIGletHeader{Hardblank: []rune(fields[0][len(figletSignature):])[0]}
+	targets := []*int{&header.Height, &header.Baseline, &header.MaxLength, &header.OldLayout, &header.CommentLines}
+	for i, target := range targets {
+		value, err := strconv.Atoi(fields[i+1])
+		if err != nil {
+			return FIGletHeader{}, fmt.Errorf("invalid FIGlet header parameter %q: %w", fields[i+1], err)
+		}
+		*target = value
+	}
+	if len(fields) > printDirParamIdx {
+		dir, err := strconv.Atoi(fields[printDirParamIdx])
+		if err != nil {
+			return FIGletHeader{}, fmt.Errorf("invalid FIGlet print direction %q: %w", fields[printDirParamIdx], err)
+		}
+		header.PrintDirection = dir
+	}
+
+	if header.Height < 1 {
+		return FIGletHeader{}, fmt.Errorf("invalid FIGlet height %d", header.Height)
+	}
+	if header.CommentLines < 0 {
+		return FIGletHeader{}, fmt.Errorf("invalid FIGlet comment line count %d", header.CommentLines)
+	}
+	return header, nil
+}
+
+// readFIGletGlyph strips endmarks from the rows of one FIGcharacter and pads
+// every row to the width of the widest one.
+//
+// Parameters:
+//   - rows: The raw font lines of a single glyph.
+//
+// Returns:
+//   - The cleaned glyph rows.
+func readFIGletGlyph(rows []string) []string {
+	glyph := make([]string, len(rows))
+	width := 0
+	for i, row := range rows {
+		glyph[i] = stripEndmarks(row)
+		width = max(width, utf8.RuneCountInString(glyph[i]))
+	}
+	for i, row := range glyph {
+		glyph[i] = row + strings.Repeat(" ", width-utf8.RuneCountInString(row))
+	}
+	return glyph
+}
+
+// stripEndmarks removes trailing whitespace and then every trailing repetition
+// of the endmark character, which is taken to be the last remaining character.
+func stripEndmarks(row string) string {
+	row = strings.TrimRight(row, " \t\r")
+	if row == "" {
+		return row
+	}
+	endmark, _ := utf8.DecodeLastRuneInString(row)
+	return strings.TrimRight(row, string(endmark))
+}
+
+// parseCodeTag parses the code point at the start of a code-tag line.
+//
+// Codes may be written in decimal, hexadecimal (0x prefix) or octal (leading
+// zero), optionally negative, and may be followed by a free-form comment.
+//
+// Parameters:
+//   - line: The code-tag line.
+//
+// Returns:
+//   - The tagged code point.
+//   - An error if the line does not start with a valid number.
+func parseCodeTag(line string) (rune, error) {
+	fields := strings.Fields(line)
+	value, err := strconv.ParseInt(fields[0], 0, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid code tag %q: %w", line, err)
+	}
+	return rune(value), nil
+}
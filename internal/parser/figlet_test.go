@@ -0,0 +1,127 @@
+package parser
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// buildTestFont returns a 2-row FIGlet font whose glyph for each character is
+// the character followed by a hardblank, using "@" endmarks.
+func buildTestFont(extra string) string {
+	var sb strings.Builder
+	sb.WriteString("flf2a$ 2 1 4 -1 2 0\n")
+	sb.WriteString("test font\n")
+	sb.WriteString("second comment line\n")
+	for r := firstPrintable; r <= lastPrintable; r++ {
+		ch := string(r)
+		if r == '@' {
+			ch = "#"
+		}
+		sb.WriteString(ch + "$@\n")
+		sb.WriteString(ch + "$@@\n")
+	}
+	sb.WriteString(extra)
+	return sb.String()
+}
+
+func TestLoadFIGletFont(t *testing.T) {
+	deutsch := strings.Repeat("D@\nD@@\n", len(deutschRunes))
+	tagged := "0x3A9  GREEK CAPITAL OMEGA\nW @\nW @@\n-5 ignored\nX@\nX@@\n"
+	fsys := fstest.MapFS{"mini.flf": {Data: []byte(buildTestFont(deutsch + tagged))}}
+
+	font, err := LoadFIGletFont(fsys, "mini.flf")
+	if err != nil {
+		t.Fatalf("LoadFIGletFont failed: %v", err)
+	}
+
+	if font.Header.Hardblank != '$' || font.Header.Height != 2 || font.Header.CommentLines != 2 {
+		t.Errorf("unexpected header: %+v", font.Header)
+	}
+	if len(font.Comments) != 2 || font.Comments[0] != "test font" {
+		t.Errorf("unexpected comments: %q", font.Comments)
+	}
+	if got := font.Glyphs['A']; len(got) != 2 || got[0] != "A$" {
+		t.Errorf("raw glyph for 'A' = %q, want hardblank preserved", got)
+	}
+	if got := font.Glyphs[196]; len(got) != 2 || got[0] != "D" {
+		t.Errorf("German glyph = %q, want %q", got, "D")
+	}
+	if got := font.Glyphs[0x3A9]; len(got) != 2 || got[0] != "W " {
+		t.Errorf("code-tagged glyph = %q, want %q", got, "W ")
+	}
+	if _, ok := font.Glyphs[-5]; ok {
+		t.Error("negative code-tagged glyph should be skipped")
+	}
+}
+
+func TestLoadFIGletReplacesHardblanks(t *testing.T) {
+	fsys := fstest.MapFS{"mini.flf": {Data: []byte(buildTestFont(""))}}
+
+	banner, err := Load(fsys, "mini.flf")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(banner) != totalChars {
+		t.Errorf("expected %d chars, got %d", totalChars, len(banner))
+	}
+	if got := banner['A'][1]; got != "A " {
+		t.Errorf("banner['A'][1] = %q, want %q", got, "A ")
+	}
+}
+
+func TestLoadFIGletErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"empty file", ""},
+		{"bad signature", "tlf2a$ 2 1 4 -1 0\n"},
+		{"missing parameters", "flf2a$ 2 1\n"},
+		{"non-numeric height", "flf2a$ x 1 4 -1 0\n"},
+		{"zero height", "flf2a$ 0 1 4 -1 0\n"},
+		{"missing comments", "flf2a$ 2 1 4 -1 5\nonly one\n"},
+		{"truncated glyphs", "flf2a$ 2 1 4 -1 0\n $@\n $@@\n"},
+		{"bad code tag", buildTestFont(strings.Repeat("D@\nD@@\n", 7) + "zzz\nX@\nX@@\n")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{"bad.flf": {Data: []byte(tt.data)}}
+			if _, err := LoadFIGlet(fsys, "bad.flf"); err == nil {
+				t.Errorf("expected error for %s, got nil", tt.name)
+			}
+		})
+	}
+}
+
+func TestReadFIGletGlyph(t *testing.T) {
+	got := readFIGletGlyph([]string{"▄█▄@", "█@@"})
+	want := []string{"▄█▄", "█  "}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readFIGletGlyph = %q, want %q", got, want)
+	}
+}
+
+func TestStripEndmarks(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"abc@", "abc"},
+		{"abc@@", "abc"},
+		{"abc##  ", "abc"},
+		{"", ""},
+		{"@@", ""},
+		{"ab¶¶", "ab"},
+		{"a¶b§", "a¶b"},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%q", tt.in), func(t *testing.T) {
+			if got := stripEndmarks(tt.in); got != tt.want {
+				t.Errorf("stripEndmarks(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+const jsonExtension = ".json"
+
+// jsonBanner is the JSON banner format: the glyph height, the glyph rows
+// keyed by code point in U+ notation and optional metadata, for example
+//
+//	{"height": 8, "glyphs": {"U+0041": ["    /\\    ", ...], ...}, "metadata": {"author": "..."}}
+type jsonBanner struct {
+	Height   int                 `json:"height"`
+	Glyphs   map[string][]string `json:"glyphs"`
+	Metadata map[string]any      `json:"metadata,omitempty"`
+}
+
+// metadata converts the document's "metadata" object, which holds the keys of
+// a txt metadata header, to Metadata.
+//
+// Returns:
+//   - The metadata, with Height set from the document.
+//   - A *ParseError wrapping ErrMetadata if a value is invalid.
+func (doc jsonBanner) metadata() (Metadata, error) {
+	meta := Metadata{Height: doc.Height}
+	keys := make([]string, 0, len(doc.Metadata))
+	for key := range doc.Metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		lower := strings.ToLower(key)
+		if lower == "height" {
+			return Metadata{}, parseError(0, -1, ErrMetadata, "metadata: height belongs at the top level of the document")
+		}
+		value := fmt.Sprint(doc.Metadata[key])
+		if list, ok := doc.Metadata[key].([]any); ok {
+			items := make([]string, len(list))
+			for i, item := range list {
+				items[i] = fmt.Sprint(item)
+			}
+			value = strings.Join(items, ",")
+		}
+		if err := meta.set(lower, value); err != nil {
+			return Metadata{}, parseError(0, -1, ErrMetadata, "metadata: %s: %v", key, err)
+		}
+	}
+	return meta, nil
+}
+
+// IsJSONPath reports whether name has the JSON banner extension (".json").
+func IsJSONPath(name string) bool {
+	return strings.EqualFold(path.Ext(name), jsonExtension)
+}
+
+// LoadJSON reads a banner in the JSON format, a document holding the glyph
+// height and an object that maps code points such as "U+0041" to arrays of
+// glyph rows. Like the txt format, all printable ASCII glyphs are required.
+//
+// Parameters:
+//   - fsys: The filesystem to read from.
+//   - path: The file path within the filesystem.
+//
+// Returns:
+//   - A Banner map containing all character definitions.
+//   - An error if the file cannot be read, or a *ParseError if the document is invalid.
+func LoadJSON(fsys fs.FS, path string) (Banner, error) {
+	banner, _, err := loadJSON(fsys, path, Strict, false)
+	return banner, err
+}
+
+// loadJSON reads a JSON banner in the given mode.
+//
+// Parameters:
+//   - fsys: The filesystem to read from.
+//   - path: The file path within the filesystem.
+//   - mode: The parsing mode; Lenient also accepts a UTF-8 byte order mark.
+//   - partial: Whether printable ASCII glyphs may be missing.
+//
+// Returns:
+//   - The Banner map.
+//   - Warnings about characters declared in the metadata coverage that a
+//     partial banner does not define.
+//   - An error if the file cannot be read, or a *ParseError if the document is invalid.
+func loadJSON(fsys fs.FS, path string, mode Mode, partial bool) (Banner, []string, error) {
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read JSON banner %q: %w", path, err)
+	}
+	if mode == Lenient {
+		data = bytes.TrimPrefix(data, []byte(utf8BOM))
+	}
+	banner, warnings, err := buildJSONBanner(data, partial)
+	if err != nil {
+		return nil, nil, withPath(err, path)
+	}
+	return banner, warnings, nil
+}
+
+// buildJSONBanner decodes and validates a JSON banner document.
+//
+// Parameters:
+//   - data: The JSON document.
+//   - partial: Whether printable ASCII glyphs may be missing.
+//
+// Returns:
+//   - The Banner map.
+//   - Warnings about declared characters a partial banner does not define.
+//   - A *ParseError if the document is malformed, a glyph is invalid or the
+//     metadata contradicts the glyphs.
+func buildJSONBanner(data []byte, partial bool) (Banner, []string, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil, parseError(0, -1, ErrEmptyFile, "empty banner file")
+	}
+	var doc jsonBanner
+	if err := json.Unmarshal(data, &doc); err != nil {
+		var syntax *json.SyntaxError
+		if errors.As(err, &syntax) {
+			line := bytes.Count(data[:syntax.Offset], []byte("\n")) + 1
+			return nil, nil, parseError(line, -1, ErrJSON, "%v", err)
+		}
+		return nil, nil, parseError(0, -1, ErrJSON, "%v", err)
+	}
+	if doc.Height < 1 {
+		return nil, nil, parseError(0, -1, ErrJSON, "height must be a positive number, got %d", doc.Height)
+	}
+
+	banner := make(Banner, len(doc.Glyphs))
+	for key, rows := range doc.Glyphs {
+		r, err := parseCodePoint(key)
+		if err != nil {
+			return nil, nil, parseError(0, -1, ErrCodePointTag, "invalid glyph key: %v", err)
+		}
+		if len(rows) != doc.Height {
+			return nil, nil, parseError(0, r, ErrMalformedGlyph, "got %d rows, expected height %d", len(rows), doc.Height)
+		}
+		banner[r] = rows
+	}
+
+	if len(banner) == 0 {
+		return nil, nil, parseError(0, -1, ErrIncomplete, "banner defines no glyphs")
+	}
+	if n := countPrintable(banner); n != totalChars && !partial {
+		return nil, nil, parseError(0, -1, ErrIncomplete, "incomplete banner: got %d chars, expected %d", n, totalChars)
+	}
+	meta, err := doc.metadata()
+	if err != nil {
+		return nil, nil, err
+	}
+	warnings, err := meta.check(banner, 0, partial)
+	if err != nil {
+		return nil, nil, err
+	}
+	return banner, warnings, nil
+}
@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// buildJSONFile returns a JSON banner with 1-row glyphs from ' ' up to last.
+func buildJSONFile(last rune) string {
+	var glyphs []string
+	for r := firstPrintable; r <= last; r++ {
+		glyphs = append(glyphs, fmt.Sprintf(`"U+%04X": [%q]`, r, string(r)))
+	}
+	return `{"height": 1, "glyphs": {` + strings.Join(glyphs, ",\n") + `}}`
+}
+
+func TestLoadJSON(t *testing.T) {
+	fsys := fstest.MapFS{"ok.json": {Data: []byte(buildJSONFile(lastPrintable))}}
+
+	banner, err := Load(fsys, "ok.json")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(banner) != totalChars || banner['~'][0] != "~" {
+		t.Errorf("got %d glyphs, '~' = %q", len(banner), banner['~'])
+	}
+}
+
+func TestLoadJSONErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		sentinel error
+		line     int
+		r        rune
+	}{
+		{"syntax", "{\n\"height\": 1,\n}", ErrJSON, 3, -1},
+		{"no height", `{"glyphs": {"U+0041": ["A"]}}`, ErrJSON, 0, -1},
+		{"row count", strings.Replace(buildJSONFile(lastPrintable), `["A"]`, `["A", "A"]`, 1), ErrMalformedGlyph, 0, 'A'},
+		{"bad key", `{"height": 1, "glyphs": {"A": ["A"]}}`, ErrCodePointTag, 0, -1},
+		{"incomplete", buildJSONFile('Z'), ErrIncomplete, 0, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{"bad.json": {Data: []byte(tt.data)}}
+			_, err := LoadJSON(fsys, "bad.json")
+			var perr *ParseError
+			if !errors.Is(err, tt.sentinel) || !errors.As(err, &perr) {
+				t.Fatalf("error = %v, want %v", err, tt.sentinel)
+			}
+			if perr.Line != tt.line || perr.Rune != tt.r || !strings.HasPrefix(err.Error(), `failed to parse JSON banner "bad.json"`) {
+				t.Errorf("got %q at line %d, rune %d; want line %d, rune %d", err, perr.Line, perr.Rune, tt.line, tt.r)
+			}
+		})
+	}
+}
+
+func TestLoadPartialJSON(t *testing.T) {
+	fsys := fstest.MapFS{"short.json": {Data: []byte(buildJSONFile('Z'))}}
+
+	banner, warnings, err := LoadPartial(fsys, "short.json")
+	if err != nil {
+		t.Fatalf("LoadPartial failed: %v", err)
+	}
+	if len(banner) != int('Z'-' '+1) || len(warnings) != 1 || !strings.Contains(warnings[0], "got 59 chars") {
+		t.Errorf("got %d glyphs with warnings %q", len(banner), warnings)
+	}
+}
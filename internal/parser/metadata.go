@@ -0,0 +1,367 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// frontMatterDelimiter opens and closes the metadata header of a txt banner.
+const frontMatterDelimiter = "---"
+
+// Metadata describes a banner: where it comes from, under which terms it may be
+// used and what it declares about its glyphs. Every field is optional.
+//
+// In a txt banner the metadata is an optional header block before the first
+// glyph, delimited by "---" lines and holding one "key: value" pair per line:
+//
+//	---
+//	name: Standard
+//	author: Jane Doe <jane@example.com>
+//	license: MIT
+//	height: 8
+//	baseline: 6
+//	coverage: U+0020..U+007E, U+0391..U+03A9
+//	---
+//
+// Keys are case-insensitive; blank lines and lines starting with "#" are
+// ignored. JSON banners hold the same keys in a "metadata" object, with the
+// height taken from the document. FIGlet fonts provide the height and baseline
+// of their header, and may hold the other keys in a block of the same form
+// among their comment lines.
+type Metadata struct {
+	Name    string
+	Author  string
+	License string
+	// Height is the declared glyph height, or 0 if not declared.
+	Height int
+	// Baseline is the number of rows from the top of a glyph down to and
+	// including the baseline, or 0 if not declared.
+	Baseline int
+	// Coverage lists the code points the banner declares to define.
+	Coverage []CodePointRange
+	// Extra holds the values of any other keys, by lower-case key.
+	Extra map[string]string
+}
+
+// CodePointRange is an inclusive range of code points.
+type CodePointRange struct {
+	First, Last rune
+}
+
+// String returns the range in code point tag notation, such as "U+0391..U+03A9",
+// or a single code point such as "U+03A9".
+func (r CodePointRange) String() string {
+	if r.First == r.Last {
+		return formatCodePoint(r.First)
+	}
+	return formatCodePoint(r.First) + rangeSeparator + formatCodePoint(r.Last)
+}
+
+// IsZero reports whether no metadata is set.
+func (m Metadata) IsZero() bool {
+	return m.Name == "" && m.Author == "" && m.License == "" && m.Height == 0 &&
+		m.Baseline == 0 && len(m.Coverage) == 0 && len(m.Extra) == 0
+}
+
+// MetadataField is one "key: value" pair of a metadata header.
+type MetadataField struct {
+	Key, Value string
+}
+
+// Fields returns the metadata as "key: value" pairs in the order of a txt
+// metadata header: name, author, license, height, baseline, coverage, then the
+// extra keys in alphabetical order. Fields that are not set are omitted.
+//
+// Returns:
+//   - The pairs, with lower-case keys; nil for the zero Metadata.
+func (m Metadata) Fields() []MetadataField {
+	var fields []MetadataField
+	add := func(key, value string) {
+		if value != "" {
+			fields = append(fields, MetadataField{key, value})
+		}
+	}
+	add("name", m.Name)
+	add("author", m.Author)
+	add("license", m.License)
+	if m.Height != 0 {
+		add("height", strconv.Itoa(m.Height))
+	}
+	if m.Baseline != 0 {
+		add("baseline", strconv.Itoa(m.Baseline))
+	}
+	ranges := make([]string, len(m.Coverage))
+	for i, cr := range m.Coverage {
+		ranges[i] = cr.String()
+	}
+	add("coverage", strings.Join(ranges, ", "))
+
+	keys := make([]string, 0, len(m.Extra))
+	for key := range m.Extra {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		add(key, m.Extra[key])
+	}
+	return fields
+}
+
+// FormatFrontMatter returns the metadata header of a txt banner, the inverse of
+// ParseFrontMatter.
+//
+// Parameters:
+//   - meta: The metadata to write.
+//
+// Returns:
+//   - The header lines, including both delimiters; nil for the zero Metadata.
+func FormatFrontMatter(meta Metadata) []string {
+	fields := meta.Fields()
+	if len(fields) == 0 {
+		return nil
+	}
+	lines := []string{frontMatterDelimiter}
+	for _, f := range fields {
+		lines = append(lines, f.Key+": "+f.Value)
+	}
+	return append(lines, frontMatterDelimiter)
+}
+
+// figletMetadata reads the metadata block a FIGlet font may hold among its
+// comment lines, as written by FormatFrontMatter. Comments are free-form, so
+// a block that does not parse is ignored.
+//
+// Parameters:
+//   - comments: The comment lines of the font.
+//
+// Returns:
+//   - The metadata of the first well-formed block, or the zero Metadata.
+func figletMetadata(comments []string) Metadata {
+	for i, line := range comments {
+		if strings.TrimSpace(line) != frontMatterDelimiter {
+			continue
+		}
+		if meta, n, err := ParseFrontMatter(comments[i:]); err == nil && n > 0 {
+			return meta
+		}
+	}
+	return Metadata{}
+}
+
+// LoadMetadata reads the metadata of a banner, choosing the format from the
+// file extension like Load.
+//
+// Only the metadata is parsed; whether it matches the glyphs is checked when
+// the banner itself is loaded. A banner without metadata yields the zero
+// Metadata.
+//
+// Parameters:
+//   - fsys: The filesystem to read from.
+//   - name: The file path within the filesystem.
+//   - mode: The parsing mode.
+//
+// Returns:
+//   - The metadata.
+//   - An error if the file cannot be read, or a *ParseError if the metadata is invalid.
+func LoadMetadata(fsys fs.FS, name string, mode Mode) (Metadata, error) {
+	switch {
+	case IsFIGletPath(name):
+		lines, err := readLines(fsys, name, mode)
+		if err != nil {
+			return Metadata{}, fmt.Errorf("failed to read FIGlet font %q: %w", name, err)
+		}
+		if len(lines) == 0 {
+			return Metadata{}, withPath(parseError(0, -1, ErrEmptyFile, "empty font file"), name)
+		}
+		header, err := parseFIGletHeader(lines[0])
+		if err != nil {
+			return Metadata{}, withPath(parseError(1, -1, ErrFIGletHeader, "%v", err), name)
+		}
+		meta := figletMetadata(lines[1:min(len(lines), header.CommentLines+1)])
+		meta.Height, meta.Baseline = header.Height, header.Baseline
+		return meta, nil
+	case IsJSONPath(name):
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return Metadata{}, fmt.Errorf("failed to read JSON banner %q: %w", name, err)
+		}
+		if mode == Lenient {
+			data = bytes.TrimPrefix(data, []byte(utf8BOM))
+		}
+		var doc jsonBanner
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return Metadata{}, withPath(parseError(0, -1, ErrJSON, "%v", err), name)
+		}
+		meta, err := doc.metadata()
+		if err != nil {
+			return Metadata{}, withPath(err, name)
+		}
+		return meta, nil
+	}
+
+	lines, err := readLines(fsys, name, mode)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("failed to read banner file %q: %w", name, err)
+	}
+	meta, _, err := ParseFrontMatter(lines)
+	if err != nil {
+		return Metadata{}, withPath(err, name)
+	}
+	return meta, nil
+}
+
+// ParseFrontMatter parses the optional metadata header at the start of the
+// lines of a txt banner file.
+//
+// Parameters:
+//   - lines: The lines of the file.
+//
+// Returns:
+//   - The metadata, or the zero Metadata if the file has no header.
+//   - The number of lines the header occupies, including both delimiters.
+//   - A *ParseError wrapping ErrMetadata if the header is malformed.
+func ParseFrontMatter(lines []string) (Metadata, int, error) {
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != frontMatterDelimiter {
+		return Metadata{}, 0, nil
+	}
+
+	var meta Metadata
+	seen := make(map[string]bool)
+	for i := 1; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimSpace(lines[i])
+		switch {
+		case line == frontMatterDelimiter:
+			return meta, i + 1, nil
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		}
+
+		key, value, found := strings.Cut(line, ":")
+		key, value = strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value)
+		if !found || key == "" {
+			return Metadata{}, 0, parseError(lineNo, -1, ErrMetadata, "expected \"key: value\", got %q", lines[i])
+		}
+		if seen[key] {
+			return Metadata{}, 0, parseError(lineNo, -1, ErrMetadata, "duplicate key %q", key)
+		}
+		seen[key] = true
+		if err := meta.set(key, value); err != nil {
+			return Metadata{}, 0, parseError(lineNo, -1, ErrMetadata, "%s: %v", key, err)
+		}
+	}
+	return Metadata{}, 0, parseError(len(lines), -1, ErrMetadata, "metadata header is not closed with %q", frontMatterDelimiter)
+}
+
+// set assigns the value of one metadata key.
+//
+// Parameters:
+//   - key: The lower-case key.
+//   - value: The value with surrounding whitespace removed.
+//
+// Returns:
+//   - An error if the value is invalid for the key.
+func (m *Metadata) set(key, value string) error {
+	var err error
+	switch key {
+	case "name":
+		m.Name = value
+	case "author":
+		m.Author = value
+	case "license":
+		m.License = value
+	case "height":
+		m.Height, err = parsePositive(value)
+	case "baseline":
+		m.Baseline, err = parsePositive(value)
+	case "coverage":
+		m.Coverage, err = parseCoverage(value)
+	default:
+		if m.Extra == nil {
+			m.Extra = make(map[string]string)
+		}
+		m.Extra[key] = value
+	}
+	return err
+}
+
+// parsePositive parses a positive integer metadata value.
+func parsePositive(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("expected a positive number, got %q", value)
+	}
+	return n, nil
+}
+
+// parseCoverage parses a comma-separated list of code points and ranges in
+// code point tag notation, such as "U+0020..U+007E, U+03A9".
+func parseCoverage(value string) ([]CodePointRange, error) {
+	var ranges []CodePointRange
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		first, last, ok, err := ParseCodePointTag(item)
+		if err != nil {
+			return nil, err
+		}
+		if !ok || strings.ContainsAny(item, " \t") {
+			return nil, fmt.Errorf("expected U+XXXX or U+XXXX..U+YYYY, got %q", item)
+		}
+		ranges = append(ranges, CodePointRange{First: first, Last: last})
+	}
+	return ranges, nil
+}
+
+// check verifies that the declared metadata matches the glyphs of a banner.
+//
+// Parameters:
+//   - banner: The glyphs of the banner.
+//   - line: The line number reported in errors, such as the header's first line.
+//   - partial: Whether the banner may omit declared characters.
+//
+// Returns:
+//   - Warnings about declared characters a partial banner does not define.
+//   - A *ParseError wrapping ErrMetadata if the metadata contradicts the glyphs.
+func (m Metadata) check(banner Banner, line int, partial bool) ([]string, error) {
+	height := banner.Height()
+	if m.Height != 0 && m.Height != height {
+		return nil, parseError(line, -1, ErrMetadata, "declared height %d does not match the glyph height %d", m.Height, height)
+	}
+	if m.Baseline > height {
+		return nil, parseError(line, -1, ErrMetadata, "declared baseline %d is below the last glyph row %d", m.Baseline, height)
+	}
+
+	var missing []rune
+	for _, cr := range m.Coverage {
+		for r := cr.First; r <= cr.Last; r++ {
+			if _, ok := banner[r]; !ok {
+				missing = append(missing, r)
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return nil, nil
+	}
+	sort.Slice(missing, func(i, j int) bool { return missing[i] < missing[j] })
+	if !partial {
+		return nil, parseError(line, missing[0], ErrMetadata, "declared in the coverage but not defined (%d glyphs missing)", len(missing))
+	}
+	return []string{fmt.Sprintf("%d glyphs declared in the coverage are not defined, starting with %q (%s)",
+		len(missing), missing[0], formatCodePoint(missing[0]))}, nil
+}
+
+// shiftLines moves the line number of a *ParseError by offset, so that errors
+// found in the lines after a metadata header name lines of the whole file.
+func shiftLines(err error, offset int) error {
+	var perr *ParseError
+	if offset != 0 && errors.As(err, &perr) && perr.Line > 0 {
+		perr.Line += offset
+	}
+	return err
+}
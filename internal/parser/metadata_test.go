@@ -0,0 +1,168 @@
+package parser
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// testHeader is a complete metadata header for the 2-row banners of buildBannerFile.
+const testHeader = `---
+name: Test Font
+Author: Jane Doe <jane@example.com>
+# comment lines and blank lines are ignored
+
+license: CC-BY-4.0
+height: 2
+baseline: 1
+coverage: U+0020..U+007E, U+03A9
+source: https://example.com/font
+---
+`
+
+func TestParseFrontMatter(t *testing.T) {
+	meta, n, err := ParseFrontMatter(strings.Split(testHeader+"\nAA", "\n"))
+	if err != nil {
+		t.Fatalf("ParseFrontMatter failed: %v", err)
+	}
+	want := Metadata{
+		Name:     "Test Font",
+		Author:   "Jane Doe <jane@example.com>",
+		License:  "CC-BY-4.0",
+		Height:   2,
+		Baseline: 1,
+		Coverage: []CodePointRange{{' ', '~'}, {'Ω', 'Ω'}},
+		Extra:    map[string]string{"source": "https://example.com/font"},
+	}
+	if n != 11 || !reflect.DeepEqual(meta, want) {
+		t.Errorf("got %+v over %d lines, want %+v over 11 lines", meta, n, want)
+	}
+	if got := want.Coverage[0].String() + " " + want.Coverage[1].String(); got != "U+0020..U+007E U+03A9" {
+		t.Errorf("coverage strings = %q", got)
+	}
+
+	if meta, n, err := ParseFrontMatter([]string{"", "AA"}); err != nil || n != 0 || !meta.IsZero() {
+		t.Errorf("file without header = %+v, %d, %v", meta, n, err)
+	}
+}
+
+func TestParseFrontMatterErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		line   int
+		want   string
+	}{
+		{"unclosed", "---\nname: x", 2, "not closed"},
+		{"no colon", "---\nname x\n---", 2, `expected "key: value"`},
+		{"duplicate", "---\nname: x\nNAME: y\n---", 3, `duplicate key "name"`},
+		{"bad height", "---\nheight: tall\n---", 2, "height: expected a positive number"},
+		{"bad coverage", "---\ncoverage: U+0020, A\n---", 2, "expected U+XXXX"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ParseFrontMatter(strings.Split(tt.header, "\n"))
+			var perr *ParseError
+			if !errors.Is(err, ErrMetadata) || !errors.As(err, &perr) {
+				t.Fatalf("error = %v, want ErrMetadata", err)
+			}
+			if perr.Line != tt.line || !strings.Contains(perr.Reason, tt.want) {
+				t.Errorf("got %q at line %d, want %q at line %d", perr.Reason, perr.Line, tt.want, tt.line)
+			}
+		})
+	}
+}
+
+func TestLoadBannerWithMetadata(t *testing.T) {
+	fsys := fstest.MapFS{
+		"meta.txt":  {Data: []byte(strings.Replace(testHeader, ", U+03A9", "", 1) + buildBannerFile(2))},
+		"plain.txt": {Data: []byte(buildBannerFile(2))},
+	}
+
+	banner, err := Load(fsys, "meta.txt")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	plain, _ := Load(fsys, "plain.txt")
+	if !reflect.DeepEqual(banner, plain) {
+		t.Error("the metadata header changed the glyphs")
+	}
+
+	meta, err := LoadMetadata(fsys, "meta.txt", Strict)
+	if err != nil || meta.Author != "Jane Doe <jane@example.com>" {
+		t.Errorf("LoadMetadata = %+v, %v", meta, err)
+	}
+	if meta, err := LoadMetadata(fsys, "plain.txt", Strict); err != nil || !meta.IsZero() {
+		t.Errorf("LoadMetadata(plain.txt) = %+v, %v", meta, err)
+	}
+}
+
+func TestLoadBannerMetadataMismatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		body   string
+		line   int
+		want   string
+	}{
+		{"height", "---\nheight: 3\n---\n", buildBannerFile(2), 1, "declared height 3 does not match the glyph height 2"},
+		{"baseline", "---\nbaseline: 4\n---\n", buildBannerFile(2), 1, "declared baseline 4"},
+		{"coverage", "---\ncoverage: U+0020..U+007E, U+03A9\n---\n", buildBannerFile(2), 1, "declared in the coverage"},
+		{"glyph line", "---\nname: x\n---\n", strings.Replace(buildBannerFile(2), "\n!!", "x\n!!", 1), 7, "separator line"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{"bad.txt": {Data: []byte(tt.header + tt.body)}}
+			_, err := Load(fsys, "bad.txt")
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("error = %v, want a *ParseError", err)
+			}
+			if perr.Line != tt.line || !strings.Contains(perr.Reason, tt.want) {
+				t.Errorf("got %q at line %d, want %q at line %d", perr.Reason, perr.Line, tt.want, tt.line)
+			}
+		})
+	}
+}
+
+func TestLoadPartialCoverageWarning(t *testing.T) {
+	data := "---\ncoverage: U+0041..U+0048\n---\n" + buildPartialFile('H', "AH")
+	fsys := fstest.MapFS{"logo.txt": {Data: []byte(data)}}
+
+	banner, warnings, err := LoadPartial(fsys, "logo.txt")
+	if err != nil {
+		t.Fatalf("LoadPartial failed: %v", err)
+	}
+	if len(banner) != 2 || len(warnings) != 2 || !strings.Contains(warnings[1], "6 glyphs declared in the coverage are not defined, starting with 'B'") {
+		t.Errorf("got %d glyphs with warnings %q", len(banner), warnings)
+	}
+}
+
+func TestLoadMetadataFormats(t *testing.T) {
+	json := strings.Replace(buildJSONFile(lastPrintable), `{"height": 1,`,
+		`{"height": 1, "metadata": {"author": "Jane", "baseline": 1, "coverage": ["U+0020..U+007E"]},`, 1)
+	fsys := fstest.MapFS{
+		"meta.json": {Data: []byte(json)},
+		"font.flf":  {Data: []byte(buildTestFont(""))},
+		"bad.json":  {Data: []byte(strings.Replace(json, `"baseline": 1`, `"baseline": 2`, 1))},
+	}
+
+	if _, err := Load(fsys, "meta.json"); err != nil {
+		t.Errorf("Load(meta.json) failed: %v", err)
+	}
+	meta, err := LoadMetadata(fsys, "meta.json", Strict)
+	want := Metadata{Author: "Jane", Height: 1, Baseline: 1, Coverage: []CodePointRange{{' ', '~'}}}
+	if err != nil || !reflect.DeepEqual(meta, want) {
+		t.Errorf("LoadMetadata(meta.json) = %+v, %v; want %+v", meta, err, want)
+	}
+	if _, err := Load(fsys, "bad.json"); !errors.Is(err, ErrMetadata) {
+		t.Errorf("Load(bad.json) error = %v, want ErrMetadata", err)
+	}
+
+	meta, err = LoadMetadata(fsys, "font.flf", Strict)
+	if err != nil || meta.Height == 0 || meta.Baseline == 0 {
+		t.Errorf("LoadMetadata(font.flf) = %+v, %v", meta, err)
+	}
+}
@@ -1,8 +1,12 @@
 package parser
 
 import (
+	"fmt"
 	"os"
+	"slices"
+	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 func TestLoadBannerSpaceChar(t *testing.T) {
@@ -298,6 +302,38 @@ func TestCharWidths(t *testing.T) {
 	}
 }
 
+func TestCharWidthsWithSpacing(t *testing.T) {
+	banner := Banner{
+		'H': {"_    _ "},
+		'i': {" _ "},
+		'Ω': {"ooo"},
+	}
+
+	tests := []struct {
+		name    string
+		text    string
+		spacing int
+		want    []int
+	}{
+		{"no spacing", "Hi", 0, []int{7, 3}},
+		{"between characters only", "HiH", 2, []int{9, 5, 7}},
+		{"unknown characters skipped", "HZi", 1, []int{8, 0, 3}},
+		{"multi-byte character", "ΩH", 1, []int{4, 0, 7}},
+		{"negative", "Hi", -2, []int{5, 3}},
+		{"never negative", "iH", -5, []int{0, 7}},
+		{"single character", "H", 3, []int{7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CharWidthsWithSpacing(tt.text, banner, tt.spacing)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("CharWidthsWithSpacing(%q, %d) = %v, want %v", tt.text, tt.spacing, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestCharWidths_EmptyBanner(t *testing.T) {
 	banner := Banner{}
 	widths := CharWidths("Hello", banner)
@@ -408,3 +444,65 @@ func TestLoadBannerAllSpecialCharacters(t *testing.T) {
 		}
 	}
 }
+
+// buildBannerFile returns a banner file with glyphs of the given height, where
+// every glyph row repeats the glyph's character.
+func buildBannerFile(height int) string {
+	var sb strings.Builder
+	for r := firstPrintable; r <= lastPrintable; r++ {
+		sb.WriteString("\n")
+		for i := 0; i < height; i++ {
+			sb.WriteString(string(r) + string(r) + "\n")
+		}
+	}
+	return sb.String()
+}
+
+func TestLoadBannerInfersGlyphHeight(t *testing.T) {
+	for _, height := range []int{1, 5, 6, 8, 12} {
+		t.Run(fmt.Sprintf("height %d", height), func(t *testing.T) {
+			fsys := fstest.MapFS{"custom.txt": {Data: []byte(buildBannerFile(height))}}
+
+			banner, err := LoadBanner(fsys, "custom.txt")
+			if err != nil {
+				t.Fatalf("LoadBanner failed: %v", err)
+			}
+			if banner.Height() != height {
+				t.Errorf("Height() = %d, want %d", banner.Height(), height)
+			}
+			if len(banner) != totalChars {
+				t.Errorf("expected %d chars, got %d", totalChars, len(banner))
+			}
+			if got := banner['~'][height-1]; got != "~~" {
+				t.Errorf("last row of '~' = %q, want %q", got, "~~")
+			}
+		})
+	}
+}
+
+func TestLoadBannerNonBlankSeparator(t *testing.T) {
+	data := strings.Replace(buildBannerFile(5), "\n!!", "x\n!!", 1)
+	fsys := fstest.MapFS{"bad.txt": {Data: []byte(data)}}
+
+	_, err := LoadBanner(fsys, "bad.txt")
+	if err == nil {
+		t.Fatal("expected error for non-blank separator, got nil")
+	}
+	if !strings.Contains(err.Error(), "line 7") {
+		t.Errorf("error should mention line 7, got: %v", err)
+	}
+}
+
+func TestBannerHeight(t *testing.T) {
+	if got := (Banner{}).Height(); got != 0 {
+		t.Errorf("empty banner Height() = %d, want 0", got)
+	}
+
+	banner, err := LoadBanner(os.DirFS("../../cmd/ascii-art/testdata"), "standard.txt")
+	if err != nil {
+		t.Fatalf("LoadBanner failed: %v", err)
+	}
+	if got := banner.Height(); got != linesPerGlyph {
+		t.Errorf("standard Height() = %d, want %d", got, linesPerGlyph)
+	}
+}
@@ -0,0 +1,307 @@
+package parser
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+	"unicode/utf8"
+)
+
+// LoadPartial reads a banner that may define only some of the printable ASCII
+// glyphs, choosing the parser from the file extension like Load.
+//
+// Partial banners are meant to be combined with complete ones through Fallback.
+// A missing glyph is not an error; instead a warning describing how many
+// glyphs the banner defines is returned.
+//
+// Parameters:
+//   - fsys: The filesystem to read from.
+//   - name: The file path within the filesystem.
+//
+// Returns:
+//   - A Banner map containing the glyphs that are defined.
+//   - Warnings about the banner, such as missing glyphs.
+//   - An error if the file cannot be read or its format is invalid.
+func LoadPartial(fsys fs.FS, name string) (Banner, []string, error) {
+	return LoadPartialWithMode(fsys, name, Strict)
+}
+
+// LoadPartialWithMode is like LoadPartial but parses the file in the given mode.
+//
+// Parameters:
+//   - fsys: The filesystem to read from.
+//   - name: The file path within the filesystem.
+//   - mode: The parsing mode.
+//
+// Returns:
+//   - A Banner map containing the glyphs that are defined.
+//   - Warnings about the banner, such as missing glyphs.
+//   - An error if the file cannot be read, or a *ParseError if its format is invalid.
+func LoadPartialWithMode(fsys fs.FS, name string, mode Mode) (Banner, []string, error) {
+	switch {
+	case IsFIGletPath(name):
+		return loadPartialFIGlet(fsys, name, mode)
+	case IsJSONPath(name):
+		banner, coverage, err := loadJSON(fsys, name, mode, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		var warnings []string
+		if n := countPrintable(banner); n != totalChars {
+			warnings = append(warnings, fmt.Sprintf("banner %q: incomplete banner: got %d chars, expected %d",
+				name, n, totalChars))
+		}
+		for _, w := range coverage {
+			warnings = append(warnings, fmt.Sprintf("banner %q: %s", name, w))
+		}
+		return banner, warnings, nil
+	}
+	return loadPartialBanner(fsys, name, mode)
+}
+
+// LoadPartialBanner reads a txt banner file that may define only some glyphs.
+//
+// The file uses the regular layout, but it may stop after any glyph, it may
+// start directly with a code point tag, and a glyph whose rows are all empty
+// strings is a placeholder for a character the banner does not define. The
+// glyph height is inferred from the line count as for complete banners; for
+// shorter files the smallest height that yields whole glyphs with blank or
+// tagged separators and rows of equal width is used. Characters declared in
+// the coverage of a metadata header but not defined produce a warning.
+//
+// Parameters:
+//   - fsys: The filesystem to read from.
+//   - path: The file path within the filesystem.
+//
+// Returns:
+//   - A Banner map containing the glyphs that are defined.
+//   - Warnings about the banner, such as missing glyphs.
+//   - An error if the file cannot be read, or a *ParseError if its format is invalid.
+func LoadPartialBanner(fsys fs.FS, path string) (Banner, []string, error) {
+	return loadPartialBanner(fsys, path, Strict)
+}
+
+// loadPartialBanner reads and parses a partial txt banner file in the given mode.
+//
+// Parameters:
+//   - fsys: The filesystem to read from.
+//   - path: The file path within the filesystem.
+//   - mode: The parsing mode.
+//
+// Returns:
+//   - A Banner map containing the glyphs that are defined.
+//   - Warnings about the banner, such as missing glyphs.
+//   - An error if the file cannot be read, or a *ParseError if its format is invalid.
+func loadPartialBanner(fsys fs.FS, path string, mode Mode) (Banner, []string, error) {
+	lines, err := readLines(fsys, path, mode)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read banner file %q: %w", path, err)
+	}
+	meta, offset, err := ParseFrontMatter(lines)
+	if err != nil {
+		return nil, nil, withPath(err, path)
+	}
+	banner, warnings, err := buildPartialBanner(lines[offset:])
+	if err != nil {
+		return nil, nil, withPath(shiftLines(err, offset), path)
+	}
+	coverage, err := meta.check(banner, 1, true)
+	if err != nil {
+		return nil, nil, withPath(err, path)
+	}
+	warnings = append(warnings, coverage...)
+	for i, w := range warnings {
+		warnings[i] = fmt.Sprintf("banner %q: %s", path, w)
+	}
+	return banner, warnings, nil
+}
+
+// loadPartialFIGlet reads a FIGlet font that may end before all printable glyphs.
+//
+// Parameters:
+//   - fsys: The filesystem to read from.
+//   - path: The file path within the filesystem.
+//   - mode: The parsing mode.
+//
+// Returns:
+//   - The font's glyphs with hardblanks replaced by spaces.
+//   - Warnings about the font, such as missing glyphs.
+//   - An error if the file cannot be read, or a *ParseError if it is not a valid FIGlet font.
+func loadPartialFIGlet(fsys fs.FS, path string, mode Mode) (Banner, []string, error) {
+	lines, err := readLines(fsys, path, mode)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read FIGlet font %q: %w", path, err)
+	}
+	font, err := buildFIGletFont(lines, true)
+	if err != nil {
+		return nil, nil, withPath(err, path)
+	}
+	banner := font.Banner()
+	if len(banner) == 0 {
+		return nil, nil, withPath(parseError(0, -1, ErrIncomplete, "font defines no glyphs"), path)
+	}
+
+	var warnings []string
+	if n := countPrintable(banner); n < totalChars {
+		warnings = append(warnings, fmt.Sprintf("FIGlet font %q: incomplete font: got %d of %d printable chars",
+			path, n, totalChars))
+	}
+	return banner, warnings, nil
+}
+
+// buildPartialBanner constructs a Banner map from the lines of a banner file
+// that may define only some glyphs.
+//
+// Parameters:
+//   - lines: The raw lines from a banner file.
+//
+// Returns:
+//   - A Banner map containing the defined glyphs.
+//   - A warning if fewer than 95 glyphs are defined.
+//   - A *ParseError if the format is invalid or no glyph is defined.
+func buildPartialBanner(lines []string) (Banner, []string, error) {
+	if len(lines) == 0 {
+		return nil, nil, parseError(0, -1, ErrEmptyFile, "empty banner file")
+	}
+
+	var banner Banner
+	var err error
+	if base := ExtensionStart(lines); base%totalChars == 0 && base/totalChars >= 2 {
+		var height int
+		if height, err = inferGlyphHeight(lines); err == nil {
+			banner, err = buildGlyphs(lines, height, true)
+		}
+	} else {
+		banner, err = buildPartialGlyphs(lines)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(banner) == 0 {
+		return nil, nil, parseError(0, -1, ErrIncomplete, "banner defines no glyphs")
+	}
+	var warnings []string
+	if n := countPrintable(banner); n != totalChars {
+		warnings = append(warnings, fmt.Sprintf("incomplete banner: got %d chars, expected %d",
+			n, totalChars))
+	}
+	return banner, warnings, nil
+}
+
+// buildPartialGlyphs parses a banner file that holds fewer than the 95 ASCII
+// glyphs or starts with a code point tag, inferring its glyph height.
+//
+// The file must contain whole glyphs, so the line count is a multiple of the
+// height plus one. The smallest such height for which every header line is a
+// blank separator or a code point tag, the glyphs fit their ranges and all rows
+// of each glyph have the same width is used.
+//
+// Parameters:
+//   - lines: The raw lines from a banner file.
+//
+// Returns:
+//   - The Banner map.
+//   - A *ParseError if no height fits the lines.
+func buildPartialGlyphs(lines []string) (Banner, error) {
+	for linesPerChar := 2; linesPerChar <= len(lines); linesPerChar++ {
+		if len(lines)%linesPerChar != 0 || !glyphsRectangular(lines, linesPerChar) {
+			continue
+		}
+		if banner, err := buildGlyphs(lines, linesPerChar-1, true); err == nil {
+			return banner, nil
+		}
+	}
+	return nil, parseError(0, -1, ErrLineCount,
+		"invalid format: %d lines do not form whole glyphs with blank separators and rows of equal width", len(lines))
+}
+
+// glyphsRectangular reports whether the rows of every glyph, laid out with
+// linesPerChar lines per character, have the same width.
+func glyphsRectangular(lines []string, linesPerChar int) bool {
+	for i := 1; i < len(lines); i += linesPerChar {
+		for _, row := range lines[i : i+linesPerChar-1] {
+			if len(row) != len(lines[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isPlaceholderGlyph reports whether every row of a glyph is an empty string.
+func isPlaceholderGlyph(rows []string) bool {
+	for _, row := range rows {
+		if row != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// countPrintable returns how many printable ASCII characters (32-126) a banner defines.
+func countPrintable(banner Banner) int {
+	n := 0
+	for r := firstPrintable; r <= lastPrintable; r++ {
+		if _, ok := banner[r]; ok {
+			n++
+		}
+	}
+	return n
+}
+
+// Fallback combines a chain of banners into one banner.
+//
+// Every character is taken from the first banner in the chain that defines it,
+// so later banners only fill in glyphs missing from earlier ones. When the
+// banners have different glyph heights, every glyph is padded at the bottom
+// with blank rows of the glyph's width to the tallest height in the chain, so
+// that all glyphs are top-aligned. A Banner carries no baseline, so glyphs are
+// never shifted down to line up the baselines of the banners.
+//
+// Parameters:
+//   - chain: The banners in order of preference.
+//
+// Returns:
+//   - The combined banner; glyphs are copied, so the inputs are not modified.
+func Fallback(chain ...Banner) Banner {
+	height := 0
+	for _, b := range chain {
+		for _, glyph := range b {
+			height = max(height, len(glyph))
+		}
+	}
+
+	combined := make(Banner)
+	for _, b := range chain {
+		for r, glyph := range b {
+			if _, ok := combined[r]; ok {
+				continue
+			}
+			combined[r] = padGlyph(glyph, height)
+		}
+	}
+	return combined
+}
+
+// padGlyph returns a copy of glyph with blank rows appended up to height. The
+// blank rows are as wide as the widest row, counted in runes.
+//
+// Parameters:
+//   - glyph: The glyph rows.
+//   - height: The target number of rows.
+//
+// Returns:
+//   - The padded copy of the glyph.
+func padGlyph(glyph []string, height int) []string {
+	width := 0
+	for _, row := range glyph {
+		width = max(width, utf8.RuneCountInString(row))
+	}
+	padded := make([]string, 0, height)
+	padded = append(padded, glyph...)
+	for len(padded) < height {
+		padded = append(padded, strings.Repeat(" ", width))
+	}
+	return padded
+}
@@ -0,0 +1,149 @@
+package parser
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// buildPartialFile returns a 3-row banner file defining glyphs from ' ' up to
+// last, where only the characters in defined get ink and the others are
+// placeholders made of empty rows.
+func buildPartialFile(last rune, defined string) string {
+	var sb strings.Builder
+	for r := firstPrintable; r <= last; r++ {
+		sb.WriteString("\n")
+		for row := 0; row < 3; row++ {
+			if strings.ContainsRune(defined, r) {
+				sb.WriteString("[" + string(r) + "]")
+			}
+			sb.WriteString("\n")
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func TestLoadPartialBanner(t *testing.T) {
+	fsys := fstest.MapFS{"logo.txt": {Data: []byte(buildPartialFile('H', "AH"))}}
+
+	banner, warnings, err := LoadPartialBanner(fsys, "logo.txt")
+	if err != nil {
+		t.Fatalf("LoadPartialBanner failed: %v", err)
+	}
+	if len(banner) != 2 || banner.Height() != 3 {
+		t.Fatalf("got %d glyphs of height %d, want 2 glyphs of height 3", len(banner), banner.Height())
+	}
+	if got := banner['H']; got[0] != "[H]" {
+		t.Errorf("glyph for 'H' = %q", got)
+	}
+	if _, ok := banner['B']; ok {
+		t.Error("placeholder glyph for 'B' should be treated as missing")
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "incomplete banner: got 2 chars, expected 95") {
+		t.Errorf("warnings = %q, want an incomplete banner warning", warnings)
+	}
+}
+
+func TestLoadPartialBannerTruncated(t *testing.T) {
+	data, err := os.ReadFile("../../cmd/ascii-art/testdata/standard.txt")
+	if err != nil {
+		t.Fatalf("failed to read standard.txt: %v", err)
+	}
+	lines := strings.Split(string(data), "\n")
+	fsys := fstest.MapFS{"short.txt": {Data: []byte(strings.Join(lines[:10*9], "\n"))}}
+
+	banner, warnings, err := LoadPartial(fsys, "short.txt")
+	if err != nil {
+		t.Fatalf("LoadPartial failed: %v", err)
+	}
+	if len(banner) != 10 || banner.Height() != 8 || len(warnings) != 1 {
+		t.Errorf("got %d glyphs of height %d with warnings %q, want 10 glyphs of height 8",
+			len(banner), banner.Height(), warnings)
+	}
+
+	full, warnings, err := LoadPartial(os.DirFS("../../cmd/ascii-art/testdata"), "standard.txt")
+	if err != nil || len(full) != totalChars || len(warnings) != 0 {
+		t.Errorf("complete banner: %d glyphs, warnings %q, err %v", len(full), warnings, err)
+	}
+}
+
+func TestLoadPartialErrors(t *testing.T) {
+	testdata := os.DirFS("../../cmd/ascii-art/testdata")
+	fsys := fstest.MapFS{
+		"placeholders.txt": {Data: []byte(buildPartialFile('#', ""))},
+		"ragged.txt":       {Data: []byte("\nab\ncd\n\nef")},
+	}
+
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+		path string
+	}{
+		{"missing file", fsys, "missing.txt"},
+		{"only placeholders", fsys, "placeholders.txt"},
+		{"incomplete last glyph", fsys, "ragged.txt"},
+	}
+	for _, tt := range tests {
+		if _, _, err := LoadPartial(tt.fsys, tt.path); err == nil {
+			t.Errorf("%s: expected error, got nil", tt.name)
+		}
+	}
+
+	for _, name := range []string{"corrupted.txt", "empty.txt"} {
+		if _, _, err := LoadPartial(testdata, name); err == nil {
+			t.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
+
+func TestLoadPartialFIGlet(t *testing.T) {
+	full := buildTestFont("")
+	rows := strings.Split(full, "\n")
+	// Header, two comment lines, then 10 glyphs of 2 rows each.
+	short := strings.Join(rows[:3+10*2], "\n")
+	fsys := fstest.MapFS{"short.flf": {Data: []byte(short)}}
+
+	if _, err := Load(fsys, "short.flf"); err == nil {
+		t.Error("Load should reject an incomplete FIGlet font")
+	}
+	banner, warnings, err := LoadPartial(fsys, "short.flf")
+	if err != nil {
+		t.Fatalf("LoadPartial failed: %v", err)
+	}
+	if len(banner) != 10 || len(warnings) != 1 {
+		t.Errorf("got %d glyphs with warnings %q, want 10 glyphs and one warning", len(banner), warnings)
+	}
+	if got := banner['!']; got[0] != "! " {
+		t.Errorf("glyph for '!' = %q, want hardblank replaced", got)
+	}
+}
+
+func TestFallback(t *testing.T) {
+	logo := Banner{
+		'A': {"/\\", "--"},
+		'c': {"█▀", "▀█"},
+	}
+	base := Banner{
+		'A': {"a", "a", "a"},
+		'b': {"b ", "b ", "bb"},
+	}
+
+	got := Fallback(logo, base)
+	// Shorter glyphs stay top-aligned, padded with rows of their rune width.
+	want := Banner{
+		'A': {"/\\", "--", "  "},
+		'c': {"█▀", "▀█", "  "},
+		'b': {"b ", "b ", "bb"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Fallback() = %q, want %q", got, want)
+	}
+	if len(logo['A']) != 2 {
+		t.Error("Fallback should not modify its inputs")
+	}
+	if got := Fallback(); len(got) != 0 {
+		t.Errorf("Fallback() of empty chain = %q, want empty banner", got)
+	}
+}
@@ -0,0 +1,207 @@
+package renderer
+
+import (
+	"fmt"
+	"strings"
+
+	"ascii-art-fs/internal/parser"
+)
+
+// Align tells how a rendered block is placed within the target width.
+type Align int
+
+const (
+	// AlignLeft leaves blocks at the left edge.
+	AlignLeft Align = iota
+	// AlignRight moves blocks to the right edge.
+	AlignRight
+	// AlignCenter centers blocks, rounding the left padding down.
+	AlignCenter
+	// AlignJustify widens the spaces of a block so it fills the width. The last
+	// block of a wrapped line, and blocks without spaces, stay left-aligned.
+	AlignJustify
+)
+
+// alignNames lists the alignments by their command-line names.
+var alignNames = map[string]Align{
+	"left":    AlignLeft,
+	"right":   AlignRight,
+	"center":  AlignCenter,
+	"justify": AlignJustify,
+}
+
+// ParseAlign returns the alignment with the given name: "left", "right",
+// "center" or "justify".
+//
+// Parameters:
+//   - name: The alignment name, in any case.
+//
+// Returns:
+//   - The alignment.
+//   - An error if the name is unknown.
+func ParseAlign(name string) (Align, error) {
+	align, ok := alignNames[strings.ToLower(name)]
+	if !ok {
+		return AlignLeft, fmt.Errorf("unknown alignment %q: expected left, right, center or justify", name)
+	}
+	return align, nil
+}
+
+// Block is a segment of an input line placed in the output: its blank indent
+// and the extra columns added after its characters to justify it.
+type Block struct {
+	Segment
+	// Indent is the number of blank columns before the first glyph.
+	Indent int
+	// Extra holds the columns added after each character of the segment,
+	// indexed by byte offset from Start like parser.CharWidths; nil when no
+	// space is widened.
+	Extra []int
+	// Layout, Hardblank and LetterSpacing are those of the Options the block
+	// was placed with.
+	Layout        Layout
+	Hardblank     rune
+	LetterSpacing int
+}
+
+// Widths returns the rendered width of every character of the block's
+// segment, including the columns added by justification and letter spacing,
+// indexed by byte offset from Start like parser.CharWidths. With kerning,
+// smushing or a negative letter spacing, the columns shared by two glyphs
+// count toward the right one. The indent is not
+// included.
+//
+// Parameters:
+//   - line: The input line the block was placed in.
+//   - banner: The banner the line is rendered with.
+//
+// Returns:
+//   - The widths of the segment's characters.
+func (b Block) Widths(line string, banner parser.Banner) []int {
+	if b.fitted() {
+		_, widths, _ := fit(line[b.Start:b.End], b, banner, banner.Height())
+		return widths
+	}
+	widths := parser.CharWidths(line[b.Start:b.End], banner)
+	for i, extra := range b.Extra {
+		widths[i] += extra
+	}
+	return widths
+}
+
+// Owners tells which character drew every cell of the rendered block, which
+// locates the characters of kerned and smushed blocks, where glyphs overlap.
+// A cell where smushing merged two characters belongs to the right one,
+// unless the merged character is that of the left glyph.
+//
+// Parameters:
+//   - line: The input line the block was placed in.
+//   - banner: The banner the line is rendered with.
+//
+// Returns:
+//   - For every row and column of the block without its indent, the byte
+//     offset in line of the character that drew the cell, or -1 for blank cells.
+func (b Block) Owners(line string, banner parser.Banner) [][]int {
+	_, _, owners := fit(line[b.Start:b.End], b, banner, banner.Height())
+	for _, row := range owners {
+		for x, owner := range row {
+			if owner >= 0 {
+				row[x] = owner + b.Start
+			}
+		}
+	}
+	return owners
+}
+
+// Place wraps an input line to opts.Width like Wrap, measuring the glyphs with
+// the letter spacing, and aligns every resulting block within opts.Width
+// columns.
+//
+// Parameters:
+//   - line: An input line without newlines.
+//   - banner: The banner whose glyph widths are measured.
+//   - opts: The layout options.
+//
+// Returns:
+//   - The placed blocks, one per wrapped segment.
+func Place(line string, banner parser.Banner, opts Options) []Block {
+	segments := wrap(line, parser.CharWidthsWithSpacing(line, banner, opts.LetterSpacing), opts.Width)
+	blocks := make([]Block, len(segments))
+	for i, seg := range segments {
+		blocks[i] = Block{Segment: seg, Layout: opts.Layout, Hardblank: opts.Hardblank, LetterSpacing: opts.LetterSpacing}
+		if opts.Width <= 0 {
+			continue
+		}
+
+		part := line[seg.Start:seg.End]
+		free := opts.Width - sum(blocks[i].Widths(line, banner))
+		if free <= 0 {
+			continue
+		}
+		switch opts.Align {
+		case AlignRight:
+			blocks[i].Indent = free
+		case AlignCenter:
+			blocks[i].Indent = free / 2
+		case AlignJustify:
+			if len(segments) == 1 || i < len(segments)-1 {
+				blocks[i].Extra = justify(part, free)
+			}
+		}
+	}
+	return blocks
+}
+
+// justify spreads free columns over the spaces of part, giving the leftmost
+// spaces one column more when they do not divide evenly.
+//
+// Parameters:
+//   - part: The segment text.
+//   - free: The number of columns to add.
+//
+// Returns:
+//   - The columns added after each byte of part, or nil if it has no spaces.
+func justify(part string, free int) []int {
+	spaces := strings.Count(part, " ")
+	if spaces == 0 {
+		return nil
+	}
+	extra := make([]int, len(part))
+	n := 0
+	for i := 0; i < len(part); i++ {
+		if part[i] != ' ' {
+			continue
+		}
+		extra[i] = free / spaces
+		if n < free%spaces {
+			extra[i]++
+		}
+		n++
+	}
+	return extra
+}
+
+// RenderBlock renders a block placed by Place as rows of ASCII art, with its
+// indent and widened spaces.
+//
+// Parameters:
+//   - line: The input line the block was placed in.
+//   - b: The block.
+//   - banner: A map associating each rune with its ASCII-art representation.
+//
+// Returns:
+//   - The rows, without newlines; a single empty row for an empty segment.
+//   - An error if the banner or a character of the segment is invalid.
+func RenderBlock(line string, b Block, banner parser.Banner) ([]string, error) {
+	if len(banner) == 0 {
+		return nil, fmt.Errorf("banner is empty")
+	}
+	bannerHeight, err := validateBannerHeight(banner)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateInput(line[b.Start:b.End], banner); err != nil {
+		return nil, err
+	}
+	return renderBlock(line, b, banner, bannerHeight)
+}
@@ -0,0 +1,91 @@
+package renderer_test
+
+import (
+	"reflect"
+	"testing"
+
+	"ascii-art-fs/internal/parser"
+	"ascii-art-fs/internal/renderer"
+)
+
+// twoRowBanner has two-row glyphs one column wide, for a-z and ' '.
+func twoRowBanner() parser.Banner {
+	b := parser.Banner{' ': {" ", "_"}}
+	for r := 'a'; r <= 'z'; r++ {
+		b[r] = []string{string(r), string(r - 'a' + 'A')}
+	}
+	return b
+}
+
+func TestAlign(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  renderer.Options
+		want  string
+	}{
+		{"left", "ab", renderer.Options{Width: 6}, "ab\nAB\n"},
+		{"right", "ab", renderer.Options{Width: 6, Align: renderer.AlignRight}, "    ab\n    AB\n"},
+		{"center", "abc", renderer.Options{Width: 6, Align: renderer.AlignCenter}, " abc\n ABC\n"},
+		{"no width", "ab", renderer.Options{Align: renderer.AlignRight}, "ab\nAB\n"},
+		{"justify", "a b c", renderer.Options{Width: 8, Align: renderer.AlignJustify}, "a   b  c\nA_  B_ C\n"},
+		{"justify without spaces", "abc", renderer.Options{Width: 6, Align: renderer.AlignJustify}, "abc\nABC\n"},
+		{
+			name:  "justify keeps the last wrapped block left-aligned",
+			input: "ab cd ef",
+			opts:  renderer.Options{Width: 6, Align: renderer.AlignJustify},
+			want:  "ab  cd\nAB_ CD\nef\nEF\n",
+		},
+		{
+			name:  "each input line aligned independently",
+			input: "a\n\nabcd",
+			opts:  renderer.Options{Width: 4, Align: renderer.AlignRight},
+			want:  "   a\n   A\n\nabcd\nABCD\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderer.ASCIIWithOptions(tt.input, twoRowBanner(), tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got:\n%q\nwant:\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaceAndRenderBlock(t *testing.T) {
+	banner := twoRowBanner()
+	line := "ab cd"
+	blocks := renderer.Place(line, banner, renderer.Options{Width: 8, Align: renderer.AlignJustify})
+	if len(blocks) != 1 {
+		t.Fatalf("got %d blocks, want 1", len(blocks))
+	}
+	if got, want := blocks[0].Widths(line, banner), []int{1, 1, 4, 1, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Widths = %v, want %v", got, want)
+	}
+
+	rows, err := renderer.RenderBlock(line, renderer.Block{Segment: renderer.Segment{Start: 3, End: 5}, Indent: 2}, banner)
+	if err != nil || !reflect.DeepEqual(rows, []string{"  cd", "  CD"}) {
+		t.Errorf("RenderBlock = %q, %v", rows, err)
+	}
+	if _, err := renderer.RenderBlock("a?", renderer.Block{Segment: renderer.Segment{End: 2}}, banner); err == nil {
+		t.Error("expected an error for a character missing from the banner")
+	}
+}
+
+func TestParseAlign(t *testing.T) {
+	for name, want := range map[string]renderer.Align{
+		"left": renderer.AlignLeft, "Right": renderer.AlignRight,
+		"CENTER": renderer.AlignCenter, "justify": renderer.AlignJustify,
+	} {
+		if got, err := renderer.ParseAlign(name); err != nil || got != want {
+			t.Errorf("ParseAlign(%q) = %v, %v, want %v", name, got, err, want)
+		}
+	}
+	if _, err := renderer.ParseAlign("middle"); err == nil {
+		t.Error("expected an error for an unknown alignment")
+	}
+}
@@ -0,0 +1,314 @@
+package renderer
+
+import (
+	"fmt"
+	"strings"
+
+	"ascii-art-fs/internal/parser"
+)
+
+// Layout tells how the glyphs of a line are fitted together, like the
+// horizontal layout modes of FIGlet.
+type Layout int
+
+const (
+	// LayoutFull places every glyph at its full width.
+	LayoutFull Layout = iota
+	// LayoutKerning slides every glyph to the left until it touches the
+	// previous one.
+	LayoutKerning
+	// LayoutSmushing slides every glyph one column further than kerning when
+	// the touching characters can be merged by a FIGlet smushing rule.
+	LayoutSmushing
+)
+
+// layoutNames lists the layouts by their command-line names.
+var layoutNames = map[string]Layout{
+	"full":     LayoutFull,
+	"kerning":  LayoutKerning,
+	"kern":     LayoutKerning,
+	"smushing": LayoutSmushing,
+	"smush":    LayoutSmushing,
+}
+
+// solidBlank stands for blank cells that kerning and smushing must not remove:
+// hardblanks, the cells of the space glyph and the columns added to justify a
+// block. It is written as a space.
+const solidBlank = '\uFFFF'
+
+// hierarchyClasses lists the character classes of the hierarchy smushing rule,
+// from lowest to highest.
+var hierarchyClasses = []string{"|", `/\`, "[]", "{}", "()", "<>"}
+
+// ParseLayout returns the layout with the given name: "full", "kerning" (or
+// "kern") or "smushing" (or "smush").
+//
+// Parameters:
+//   - name: The layout name, in any case.
+//
+// Returns:
+//   - The layout.
+//   - An error if the name is unknown.
+func ParseLayout(name string) (Layout, error) {
+	layout, ok := layoutNames[strings.ToLower(name)]
+	if !ok {
+		return LayoutFull, fmt.Errorf("unknown layout %q: expected full, kerning or smushing", name)
+	}
+	return layout, nil
+}
+
+// fit lays out the glyphs of a block's segment with the block's layout.
+//
+// Glyph rows are padded to the glyph width. Every glyph after the first is
+// moved left by the shift computed by Block.shift, and the overlapping cells
+// are merged with smush. The columns shared by two glyphs count toward the width
+// of the right one, and every visible cell is owned by the character whose
+// glyph drew it, or by the right one when smushing drew a new character.
+//
+// Parameters:
+//   - part: The segment text; characters missing from the banner are skipped.
+//   - b: The block, for its layout, hardblank and justification columns.
+//   - banner: A map associating each rune with its ASCII-art representation.
+//   - height: The number of rows every glyph has.
+//
+// Returns:
+//   - The rows of the block, without indent, with solidBlank cells.
+//   - The columns of output taken by each character, indexed by byte offset
+//     in part like parser.CharWidths.
+//   - The byte offset in part of the owner of every cell, or -1 for blank cells.
+func fit(part string, b Block, banner parser.Banner, height int) ([][]rune, []int, [][]int) {
+	rows := make([][]rune, height)
+	owners := make([][]int, height)
+	widths := make([]int, len(part))
+	prev, prevWidth := -1, 0
+	for offset, ch := range part {
+		glyph, ok := banner[ch]
+		if !ok || len(glyph) != height {
+			continue
+		}
+		extra := 0
+		if offset < len(b.Extra) {
+			extra = b.Extra[offset]
+		}
+		cells := glyphCells(ch, glyph, b.Hardblank, extra)
+		width := len(cells[0])
+
+		shift := 0
+		if prev >= 0 {
+			shift = b.shift(rows, cells, prevWidth, width)
+		}
+		for y := range rows {
+			// A negative shift leaves a gap of blank columns.
+			for x := shift; x < 0; x++ {
+				rows[y] = append(rows[y], ' ')
+				owners[y] = append(owners[y], -1)
+			}
+			start := len(rows[y]) - max(shift, 0)
+			for x := 0; x < shift; x++ {
+				left, right := rows[y][start+x], cells[y][x]
+				merged := smush(left, right, b.Layout, prevWidth, width)
+				if merged == 0 {
+					merged = right
+				}
+				rows[y][start+x] = merged
+				if merged != left || isBlank(left) {
+					owners[y][start+x] = cellOwner(merged, offset)
+				}
+			}
+			for _, cell := range cells[y][max(shift, 0):] {
+				rows[y] = append(rows[y], cell)
+				owners[y] = append(owners[y], cellOwner(cell, offset))
+			}
+		}
+
+		if prev >= 0 {
+			widths[prev] -= shift
+		}
+		widths[offset] = width
+		prev, prevWidth = offset, width
+	}
+	return rows, widths, owners
+}
+
+// shift computes how many columns the next glyph moves into the rows laid out
+// so far: the overlap of the block's layout, less the letter spacing. A
+// negative letter spacing adds to the overlap only as far as kerning allows,
+// so that glyphs overlap where they are blank. A negative result is a gap.
+//
+// Parameters:
+//   - rows: The rows laid out so far.
+//   - cells: The cells of the next glyph.
+//   - prevWidth: The width of the previous glyph.
+//   - width: The width of the next glyph.
+//
+// Returns:
+//   - The number of overlapping columns, or minus the number of gap columns.
+func (b Block) shift(rows, cells [][]rune, prevWidth, width int) int {
+	shift := 0
+	if b.Layout != LayoutFull {
+		shift = overlap(rows, cells, b.Layout, prevWidth, width)
+	}
+	if b.LetterSpacing >= 0 {
+		return shift - b.LetterSpacing
+	}
+	kerned := overlap(rows, cells, LayoutKerning, prevWidth, width)
+	return max(shift, min(shift-b.LetterSpacing, kerned))
+}
+
+// fitted reports whether the block is rendered by fit rather than by writing
+// its glyph rows side by side.
+func (b Block) fitted() bool {
+	return b.Layout != LayoutFull || b.LetterSpacing != 0
+}
+
+// isBlank reports whether a laid-out cell shows nothing.
+func isBlank(cell rune) bool {
+	return cell == ' ' || cell == solidBlank
+}
+
+// cellOwner returns offset as the owner of a visible cell, and -1 for a blank one.
+func cellOwner(cell rune, offset int) int {
+	if isBlank(cell) {
+		return -1
+	}
+	return offset
+}
+
+// glyphCells converts a glyph to rows of cells of equal width, with its
+// hardblanks, the cells of the space glyph and extra justification columns
+// turned into solidBlank.
+func glyphCells(ch rune, glyph []string, hardblank rune, extra int) [][]rune {
+	width := 0
+	for _, row := range glyph {
+		width = max(width, len([]rune(row)))
+	}
+
+	cells := make([][]rune, len(glyph))
+	for y, row := range glyph {
+		cells[y] = make([]rune, 0, width+extra)
+		for _, r := range row {
+			if (hardblank != 0 && r == hardblank) || (ch == ' ' && r == ' ') {
+				r = solidBlank
+			}
+			cells[y] = append(cells[y], r)
+		}
+		for len(cells[y]) < width {
+			blank := ' '
+			if ch == ' ' {
+				blank = solidBlank
+			}
+			cells[y] = append(cells[y], blank)
+		}
+		for i := 0; i < extra; i++ {
+			cells[y] = append(cells[y], solidBlank)
+		}
+	}
+	return cells
+}
+
+// overlap computes how many columns the next glyph can move into the rows laid
+// out so far, like FIGlet's smush amount: the blank columns between the
+// last ink of every row and the first ink of the glyph's row, plus one when
+// the two touching characters smush. The overlap never exceeds the width of
+// either glyph.
+//
+// Parameters:
+//   - rows: The rows laid out so far.
+//   - cells: The cells of the next glyph.
+//   - layout: The layout mode.
+//   - prevWidth: The width of the previous glyph.
+//   - width: The width of the next glyph.
+//
+// Returns:
+//   - The number of overlapping columns.
+func overlap(rows, cells [][]rune, layout Layout, prevWidth, width int) int {
+	amount := min(prevWidth, width)
+	for y, row := range rows {
+		end := len(row) - 1
+		for end >= 0 && row[end] == ' ' {
+			end--
+		}
+		start := 0
+		for start < len(cells[y]) && cells[y][start] == ' ' {
+			start++
+		}
+
+		rowAmount := start + len(row) - 1 - end
+		if end >= 0 && start < len(cells[y]) && smush(row[end], cells[y][start], layout, prevWidth, width) != 0 {
+			rowAmount++
+		}
+		amount = min(amount, rowAmount)
+	}
+	return amount
+}
+
+// smush merges two overlapping characters with the controlled smushing rules
+// of FIGlet: hardblank, equal character, underscore, hierarchy, opposite pair
+// and big X. Blank cells always give way to the other character. Glyphs
+// narrower than two columns are never smushed, as in FIGlet.
+//
+// Parameters:
+//   - left: The character of the rows laid out so far.
+//   - right: The character of the next glyph.
+//   - layout: The layout mode; only LayoutSmushing merges two visible characters.
+//   - prevWidth: The width of the previous glyph.
+//   - width: The width of the next glyph.
+//
+// Returns:
+//   - The merged character, or 0 if the characters cannot be merged.
+func smush(left, right rune, layout Layout, prevWidth, width int) rune {
+	switch {
+	case left == ' ':
+		return right
+	case right == ' ':
+		return left
+	case layout != LayoutSmushing || prevWidth < 2 || width < 2:
+		return 0
+	case left == solidBlank && right == solidBlank:
+		return left
+	case left == solidBlank || right == solidBlank:
+		return 0
+	case left == right:
+		return left
+	case left == '_' && strings.ContainsRune(`|/\[]{}()<>`, right):
+		return right
+	case right == '_' && strings.ContainsRune(`|/\[]{}()<>`, left):
+		return left
+	}
+
+	if l, r := hierarchyClass(left), hierarchyClass(right); l >= 0 && r >= 0 && l != r {
+		if l > r {
+			return left
+		}
+		return right
+	}
+
+	switch string([]rune{left, right}) {
+	case "[]", "][", "{}", "}{", "()", ")(":
+		return '|'
+	case `/\`:
+		return '|'
+	case `\/`:
+		return 'Y'
+	case "><":
+		return 'X'
+	}
+	return 0
+}
+
+// hierarchyClass returns the index of the hierarchy class of a character, or
+// -1 if it belongs to none.
+func hierarchyClass(r rune) int {
+	for i, class := range hierarchyClasses {
+		if strings.ContainsRune(class, r) {
+			return i
+		}
+	}
+	return -1
+}
+
+// cellsString converts a row of laid-out cells to text, writing solidBlank
+// cells as spaces.
+func cellsString(row []rune) string {
+	return strings.ReplaceAll(string(row), string(solidBlank), " ")
+}
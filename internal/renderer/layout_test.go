@@ -0,0 +1,187 @@
+package renderer_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"ascii-art-fs/internal/parser"
+	"ascii-art-fs/internal/renderer"
+)
+
+func TestSmushingRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		left, right string
+		want        string
+	}{
+		{"equal character", "|", "|", ".|."},
+		{"underscore left", "_", "/", "./."},
+		{"underscore right", `\`, "_", `.\.`},
+		{"hierarchy", "|", "(", ".(."},
+		{"hierarchy higher class on the left", "<", "[", ".<."},
+		{"opposite brackets", "[", "]", ".|."},
+		{"opposite parentheses", ")", "(", ".|."},
+		{"big X slashes", "/", `\`, ".|."},
+		{"big X backslashes", `\`, "/", ".Y."},
+		{"big X angles", ">", "<", ".X."},
+		{"no rule for angles facing out", "<", ">", ".<>."},
+		{"no rule for letters", "x", "y", ".xy."},
+		{"hardblanks", "$", "$", ". ."},
+		{"hardblank and ink", "$", "x", ". x."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			banner := parser.Banner{'a': {"." + tt.left}, 'b': {tt.right + "."}}
+			got, err := renderer.ASCIIWithOptions("ab", banner, renderer.Options{Layout: renderer.LayoutSmushing, Hardblank: '$'})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want+"\n" {
+				t.Errorf("got %q, want %q", got, tt.want+"\n")
+			}
+		})
+	}
+}
+
+func TestLayouts(t *testing.T) {
+	banner := parser.Banner{
+		' ': {"  ", "  "},
+		'a': {"a  ", "aa "},
+		'b': {"  b", " bb"},
+		'c': {"  a", " aa"},
+		'i': {"|", "|"},
+	}
+	tests := []struct {
+		name   string
+		input  string
+		layout renderer.Layout
+		want   string
+	}{
+		{"full", "ab", renderer.LayoutFull, "a    b\naa  bb\n"},
+		{"kerning slides until the glyphs touch", "ab", renderer.LayoutKerning, "a  b\naabb\n"},
+		{"smushing keeps different characters apart", "ab", renderer.LayoutSmushing, "a  b\naabb\n"},
+		{"smushing merges equal characters", "ac", renderer.LayoutSmushing, "a a\naaa\n"},
+		{"space glyph is kept", "a b", renderer.LayoutKerning, "a    b\naa  bb\n"},
+		{"one-column glyphs never smush", "ii", renderer.LayoutSmushing, "||\n||\n"},
+		{"first glyph keeps its margin", "ba", renderer.LayoutKerning, "  ba  \n bbaa \n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderer.ASCIIWithOptions(tt.input, banner, renderer.Options{Layout: tt.layout})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got:\n%q\nwant:\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLayoutWidths(t *testing.T) {
+	banner := parser.Banner{
+		' ': {"  ", "  "},
+		'a': {"a  ", "aa "},
+		'b': {"  b", " bb"},
+		'c': {"  a", " aa"},
+		'x': {"xxx", "xxx"},
+	}
+	// Shared columns count toward the glyph on the right, so the widths add
+	// up to the rendered width and color the columns each glyph moved into.
+	line := "ab"
+	blocks := renderer.Place(line, banner, renderer.Options{Layout: renderer.LayoutKerning})
+	if got, want := blocks[0].Widths(line, banner), []int{1, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("kerning widths = %v, want %v", got, want)
+	}
+
+	// Every visible cell belongs to the character that drew it; the smushed
+	// cell belongs to the left glyph here, since the merged 'a' is its own.
+	line = "xac"
+	blocks = renderer.Place(line, banner, renderer.Options{Layout: renderer.LayoutSmushing})
+	want := [][]int{{1, -1, 2}, {1, 1, 2}}
+	if got := blocks[0].Owners(line, banner)[:2]; !reflect.DeepEqual(got[0][3:], want[0]) || !reflect.DeepEqual(got[1][3:], want[1]) {
+		t.Errorf("smushing owners = %v, want %v after the 'x'", got, want)
+	}
+
+	// Alignment measures the kerned width.
+	got, err := renderer.ASCIIWithOptions("ab", banner, renderer.Options{Width: 8, Align: renderer.AlignRight, Layout: renderer.LayoutKerning})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "    a  b\n    aabb\n"; got != want {
+		t.Errorf("right-aligned kerning: got %q, want %q", got, want)
+	}
+
+	// Justified columns are kept by kerning.
+	got, err = renderer.ASCIIWithOptions("a b", banner, renderer.Options{Width: 10, Align: renderer.AlignJustify, Layout: renderer.LayoutKerning})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "a        b\naa      bb\n"; got != want {
+		t.Errorf("justified kerning: got %q, want %q", got, want)
+	}
+}
+
+func TestParseLayout(t *testing.T) {
+	for name, want := range map[string]renderer.Layout{
+		"full": renderer.LayoutFull, "Kerning": renderer.LayoutKerning, "kern": renderer.LayoutKerning,
+		"SMUSHING": renderer.LayoutSmushing, "smush": renderer.LayoutSmushing,
+	} {
+		if got, err := renderer.ParseLayout(name); err != nil || got != want {
+			t.Errorf("ParseLayout(%q) = %v, %v, want %v", name, got, err, want)
+		}
+	}
+	if _, err := renderer.ParseLayout("fitted"); err == nil {
+		t.Error("expected an error for an unknown layout")
+	}
+}
+
+func TestSpacing(t *testing.T) {
+	banner := parser.Banner{
+		' ': {"  ", "  "},
+		'a': {"a  ", "aa "},
+		'b': {"  b", " bb"},
+	}
+	tests := []struct {
+		name  string
+		input string
+		opts  renderer.Options
+		want  string
+	}{
+		{"letter spacing", "ab", renderer.Options{LetterSpacing: 1}, "a     b\naa   bb\n"},
+		{"no spacing after the last glyph", "a", renderer.Options{LetterSpacing: 3}, "a  \naa \n"},
+		{"negative letter spacing", "ab", renderer.Options{LetterSpacing: -1}, "a   b\naa bb\n"},
+		{"negative letter spacing stops at ink", "ab", renderer.Options{LetterSpacing: -5}, "a  b\naabb\n"},
+		{"letter spacing after kerning", "ab", renderer.Options{Layout: renderer.LayoutKerning, LetterSpacing: 1}, "a   b\naa bb\n"},
+		{"line spacing", "a\nb", renderer.Options{LineSpacing: 2}, "a  \naa \n\n\n  b\n bb\n"},
+		{"line spacing around empty lines", "a\n\nb", renderer.Options{LineSpacing: 1}, "a  \naa \n\n\n\n  b\n bb\n"},
+		{"line spacing between wrapped blocks", "a b", renderer.Options{Width: 4, LineSpacing: 1}, "a  \naa \n\n  b\n bb\n"},
+		{"wrapping measures letter spacing", "ab", renderer.Options{Width: 6, LetterSpacing: 1}, "a  \naa \n  b\n bb\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderer.ASCIIWithOptions(tt.input, banner, tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got:\n%q\nwant:\n%q", got, tt.want)
+			}
+
+			var streamed strings.Builder
+			if err := renderer.RenderWithOptions(&streamed, strings.NewReader(tt.input), banner, tt.opts); err != nil {
+				t.Fatalf("RenderWithOptions: unexpected error: %v", err)
+			}
+			if streamed.String() != tt.want {
+				t.Errorf("RenderWithOptions got:\n%q\nwant:\n%q", streamed.String(), tt.want)
+			}
+		})
+	}
+
+	// The spacing counts toward the glyph before it.
+	blocks := renderer.Place("ab", banner, renderer.Options{LetterSpacing: 2})
+	if got, want := blocks[0].Widths("ab", banner), []int{5, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Widths = %v, want %v", got, want)
+	}
+}
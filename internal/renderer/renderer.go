@@ -1,42 +1,80 @@
 // Package renderer provides functionality for converting input text into ASCII art
 // using predefined banner character definitions.
 //
-// The renderer processes printable ASCII characters (range 32–126) and renders each
-// character as an ASCII-art block with a fixed height (bannerHeight).
+// The renderer processes every printable character the banner defines (the
+// printable ASCII range 32–126 for the bundled banners, plus any extended glyphs)
+// and renders each character as an ASCII-art block whose height is the banner's
+// glyph height.
 // Newline characters ('\n') are treated as line separators and produce empty output lines.
 //
 // Responsibilities of this package:
-//   - Validate input characters
+//   - Validate input characters against the banner's coverage
 //   - Validate banner integrity
-//   - Render ASCII-art output
+//   - Render ASCII-art output, as a string or streamed line by line to a writer
+//   - Wrap and align rendered lines to a width
+//   - Fit glyphs together at full width, kerned or smushed with the FIGlet rules
+//   - Stack glyphs vertically, one column per input line
 //
 // Any invalid input or malformed banner data results in an error.
 package renderer
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"strings"
+	"unicode"
+
+	"ascii-art-fs/internal/parser"
 )
 
-const bannerHeight = 8
+// Options adjusts how text is laid out. The zero value renders every input line
+// as a single block, as ASCII and Render do.
+type Options struct {
+	// Width is the maximum width of a rendered block in columns. Longer lines
+	// are wrapped with Wrap. 0 disables wrapping.
+	Width int
+	// Align places every block within Width columns. It has no effect when
+	// Width is 0.
+	Align Align
+	// Layout fits the glyphs of a line together at full width, kerned or
+	// smushed.
+	Layout Layout
+	// Hardblank is the character glyphs use for blanks that kerning and
+	// smushing must keep, such as the hardblank of a FIGlet font read with
+	// parser.LoadFIGletFont. It is rendered as a space; 0 means none.
+	Hardblank rune
+	// LetterSpacing is the number of blank columns added between two glyphs,
+	// after the layout has fitted them together. A negative spacing overlaps
+	// glyphs by up to that many columns, only where their cells are blank.
+	LetterSpacing int
+	// LineSpacing is the number of blank rows added between two rendered
+	// blocks, or between two glyphs when Vertical is set. It must not be
+	// negative.
+	LineSpacing int
+	// Vertical stacks the glyphs of every input line top to bottom, with the
+	// lines as columns side by side, as Vertical does.
+	Vertical bool
+}
 
 // ASCII converts an input string into ASCII art using the provided banner map.
 //
-// The input may contain printable ASCII characters (codes 32–126) and newline
-// characters ('\n'). Newlines are treated as line separators and are not rendered
-// as visible characters.
+// The input may contain any character the banner defines and newline characters
+// ('\n'). Newlines are treated as line separators and are not rendered as
+// visible characters.
 //
 // Rendering rules:
 //   - Empty input or input consisting only of a single newline returns an empty result.
 //   - Consecutive newline characters produce empty output lines.
-//   - Each non-empty input line is rendered as a block of bannerHeight ASCII-art rows.
+//   - Each non-empty input line is rendered as a block of banner.Height() ASCII-art rows.
 //   - A trailing newline does not produce an extra ASCII-art block.
 //
 // Validation rules:
-//   - Input must contain only printable ASCII characters (excluding '\n').
+//   - Input must not contain control characters (excluding '\n').
 //   - Banner map must not be empty.
-//   - Every character used in input must exist in the banner map.
-//   - Each banner entry must contain exactly bannerHeight rows.
+//   - Every character used in input must exist in the banner map; all missing
+//     characters are reported together.
+//   - Every banner entry must have the same number of rows.
 //
 // Parameters:
 //   - input: The text to render as ASCII art.
@@ -45,13 +83,32 @@ const bannerHeight = 8
 // Returns:
 //   - The rendered ASCII-art string.
 //   - An error if input validation or banner validation fails.
-func ASCII(input string, banner map[rune][]string) (string, error) {
-	var result strings.Builder
+func ASCII(input string, banner parser.Banner) (string, error) {
+	return ASCIIWithOptions(input, banner, Options{})
+}
 
-	if err := validateInput(input); err != nil {
-		return "", err
+// ASCIIWithOptions converts an input string into ASCII art like ASCII, laying
+// out every input line according to opts.
+//
+// Parameters:
+//   - input: The text to render as ASCII art.
+//   - banner: A map associating each rune with its ASCII-art representation.
+//   - opts: The layout options.
+//
+// Returns:
+//   - The rendered ASCII-art string.
+//   - An error if input validation or banner validation fails.
+func ASCIIWithOptions(input string, banner parser.Banner, opts Options) (string, error) {
+	if opts.Vertical {
+		rows, _, err := Vertical(input, banner, opts)
+		if err != nil || rows == nil {
+			return "", err
+		}
+		return strings.Join(rows, "\n") + "\n", nil
 	}
 
+	var result strings.Builder
+
 	parts := strings.Split(input, "\n")
 
 	if len(parts) > 0 && parts[len(parts)-1] == "" {
@@ -66,26 +123,228 @@ func ASCII(input string, banner map[rune][]string) (string, error) {
 		return "", fmt.Errorf("banner is empty")
 	}
 
-	for _, line := range parts {
-		// Handle empty lines produced by consecutive newline characters
-		if line == "" {
-			result.WriteString("\n")
-			continue
+	if err := validateInput(input, banner); err != nil {
+		return "", err
+	}
+
+	bannerHeight, err := validateBannerHeight(banner)
+	if err != nil {
+		return "", err
+	}
+
+	for i, line := range parts {
+		block, err := renderBlocks(line, banner, bannerHeight, opts, i == 0)
+		if err != nil {
+			return "", err
+		}
+		result.WriteString(block)
+	}
+
+	return result.String(), nil
+}
+
+// Render streams ASCII art for the text read from r to w.
+//
+// The input is processed one line at a time: each line is validated, rendered
+// as a block of banner.Height() rows and written to w before the next line is
+// read, so memory use depends on the longest line rather than on the input
+// size. Lines may end with "\n" or "\r\n". The output is the same as ASCII's
+// for the same input with "\n" line endings.
+//
+// Validation happens per line, so a line with characters the banner does not
+// define stops the rendering after the blocks of the previous lines have been
+// written.
+//
+// Parameters:
+//   - w: The destination for the ASCII art.
+//   - r: The text to render.
+//   - banner: A map associating each rune with its ASCII-art representation.
+//
+// Returns:
+//   - An error if the banner or a line is invalid, or if reading r or writing
+//     w fails; read and write errors are wrapped.
+func Render(w io.Writer, r io.Reader, banner parser.Banner) error {
+	return RenderWithOptions(w, r, banner, Options{})
+}
+
+// RenderWithOptions streams ASCII art like Render, laying out every input line
+// according to opts. Vertical art needs every line to lay out the columns, so
+// with opts.Vertical the whole input is read before anything is written.
+//
+// Parameters:
+//   - w: The destination for the ASCII art.
+//   - r: The text to render.
+//   - banner: A map associating each rune with its ASCII-art representation.
+//   - opts: The layout options.
+//
+// Returns:
+//   - An error if the banner or a line is invalid, or if reading r or writing
+//     w fails; read and write errors are wrapped.
+func RenderWithOptions(w io.Writer, r io.Reader, banner parser.Banner, opts Options) error {
+	if len(banner) == 0 {
+		return fmt.Errorf("banner is empty")
+	}
+	bannerHeight, err := validateBannerHeight(banner)
+	if err != nil {
+		return err
+	}
+
+	if opts.Vertical {
+		input, err := io.ReadAll(r)
+		if err != nil {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+		art, err := ASCIIWithOptions(string(input), banner, opts)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, art); err != nil {
+			return fmt.Errorf("failed to write ASCII art: %w", err)
+		}
+		return nil
+	}
+
+	reader := bufio.NewReader(r)
+	for first := true; ; first = false {
+		line, readErr := reader.ReadString('\n')
+		if readErr != nil && readErr != io.EOF {
+			return fmt.Errorf("failed to read input: %w", readErr)
+		}
+		if readErr == io.EOF && line == "" {
+			return nil
+		}
+
+		// CRLF line endings, as in text saved on Windows, end a line too.
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+		if err := validateInput(line, banner); err != nil {
+			return err
+		}
+		block, err := renderBlocks(line, banner, bannerHeight, opts, first)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, block); err != nil {
+			return fmt.Errorf("failed to write ASCII art: %w", err)
+		}
+
+		if readErr == io.EOF {
+			return nil
+		}
+	}
+}
+
+// renderBlocks renders one input line without newlines, wrapped and aligned
+// according to opts, as one block per wrapped segment, with opts.LineSpacing
+// blank rows before every block but the first of the output.
+//
+// Parameters:
+//   - line: The line to render.
+//   - banner: The banner map containing ASCII-art definitions.
+//   - bannerHeight: The number of rows every glyph has.
+//   - opts: The layout options.
+//   - first: Whether line is the first line of the output.
+//
+// Returns:
+//   - The rows of the blocks, each ending in '\n'.
+//   - An error if a character of line is not in the banner.
+func renderBlocks(line string, banner parser.Banner, bannerHeight int, opts Options, first bool) (string, error) {
+	var blocks strings.Builder
+	for i, b := range Place(line, banner, opts) {
+		rows, err := renderBlock(line, b, banner, bannerHeight)
+		if err != nil {
+			return "", err
+		}
+		if i > 0 || !first {
+			blocks.WriteString(strings.Repeat("\n", max(0, opts.LineSpacing)))
+		}
+		for _, row := range rows {
+			blocks.WriteString(row)
+			blocks.WriteString("\n")
+		}
+	}
+	return blocks.String(), nil
+}
+
+// renderBlock renders the segment of line covered by a placed block as rows,
+// indented and with its spaces widened as the block specifies.
+//
+// Parameters:
+//   - line: The input line the block was placed in.
+//   - b: The placed block.
+//   - banner: The banner map containing ASCII-art definitions.
+//   - bannerHeight: The number of rows every glyph has.
+//
+// Returns:
+//   - The rows without newlines; a single empty row for an empty segment.
+//   - An error if a character of the segment is not in the banner.
+func renderBlock(line string, b Block, banner parser.Banner, bannerHeight int) ([]string, error) {
+	part := line[b.Start:b.End]
+	// Handle empty lines produced by consecutive newline characters
+	if part == "" {
+		return []string{""}, nil
+	}
+
+	indent := strings.Repeat(" ", b.Indent)
+	if b.fitted() {
+		for _, ch := range part {
+			if _, err := validateBannerCharacters(ch, banner, bannerHeight); err != nil {
+				return nil, err
+			}
 		}
+		cells, _, _ := fit(part, b, banner, bannerHeight)
+		rows := make([]string, len(cells))
+		for i, row := range cells {
+			rows[i] = indent + cellsString(row)
+		}
+		return rows, nil
+	}
 
-		for i := 0; i < bannerHeight; i++ {
-			for _, ch := range line {
-				value, err := validateBannerCharacters(ch, banner)
-				if err != nil {
-					return "", err
-				}
-				result.WriteString(value[i])
+	rows := make([]string, bannerHeight)
+	for i := range rows {
+		var row strings.Builder
+		row.WriteString(indent)
+		for offset, ch := range part {
+			value, err := validateBannerCharacters(ch, banner, bannerHeight)
+			if err != nil {
+				return nil, err
+			}
+			if b.Hardblank != 0 {
+				row.WriteString(strings.ReplaceAll(value[i], string(b.Hardblank), " "))
+			} else {
+				row.WriteString(value[i])
+			}
+			if offset < len(b.Extra) && b.Extra[offset] > 0 {
+				row.WriteString(strings.Repeat(" ", b.Extra[offset]))
 			}
-			result.WriteString("\n")
 		}
+		rows[i] = row.String()
 	}
+	return rows, nil
+}
 
-	return result.String(), nil
+// validateBannerHeight determines the glyph height of the banner and checks that
+// every entry has exactly that many rows.
+//
+// Parameters:
+//   - banner: The banner map containing ASCII-art definitions.
+//
+// Returns:
+//   - The glyph height shared by all banner entries.
+//   - An error if the height is zero or any entry has a different number of rows.
+func validateBannerHeight(banner parser.Banner) (int, error) {
+	bannerHeight := banner.Height()
+	if bannerHeight == 0 {
+		return 0, fmt.Errorf("banner glyphs have no rows")
+	}
+	for ch, value := range banner {
+		if len(value) != bannerHeight {
+			return 0, fmt.Errorf(
+				"banner entry for %c (ASCII %d) has %d lines, expected %d",
+				ch, ch, len(value), bannerHeight,
+			)
+		}
+	}
+	return bannerHeight, nil
 }
 
 // validateBannerCharacters validates that a character exists in the banner map
@@ -94,15 +353,16 @@ func ASCII(input string, banner map[rune][]string) (string, error) {
 // Parameters:
 //   - ch: The character to validate.
 //   - banner: The banner map containing ASCII-art definitions.
+//   - bannerHeight: The number of rows every glyph must have.
 //
 // Returns:
 //   - The ASCII-art rows corresponding to the character.
 //   - An error if the character does not exist in the banner
 //     or if it does not contain exactly bannerHeight rows.
-func validateBannerCharacters(ch rune, banner map[rune][]string) ([]string, error) {
+func validateBannerCharacters(ch rune, banner parser.Banner, bannerHeight int) ([]string, error) {
 	value, exists := banner[ch]
 	if !exists {
-		return []string{}, fmt.Errorf("character %c (ASCII %d) not found in banner", ch, ch)
+		return []string{}, fmt.Errorf("character %c (U+%04X) not found in banner", ch, ch)
 	}
 	if len(value) != bannerHeight {
 		return []string{}, fmt.Errorf(
@@ -113,25 +373,42 @@ func validateBannerCharacters(ch rune, banner map[rune][]string) ([]string, erro
 	return value, nil
 }
 
-// validateInput checks whether the input string contains only valid characters.
+// validateInput checks whether every character of the input string can be
+// rendered with the banner.
 //
-// Valid characters are printable ASCII characters (codes 32–126) and newline
-// characters ('\n'). The function returns an error as soon as an invalid character
-// is encountered.
+// Newline characters ('\n') are always valid. Control characters are rejected as
+// soon as they are encountered; characters the banner does not define are
+// collected so that all of them are reported in a single error.
 //
 // Parameters:
 //   - input: The string to validate.
+//   - banner: The banner map whose glyphs define the supported characters.
 //
 // Returns:
-//   - An error if invalid characters are found, nil otherwise.
-func validateInput(input string) error {
+//   - An error if invalid or unsupported characters are found, nil otherwise.
+func validateInput(input string, banner parser.Banner) error {
+	var missing []string
+	seen := make(map[rune]bool)
+
 	for _, ch := range input {
 		if ch == '\n' {
 			continue
 		}
-		if ch < 32 || ch > 126 {
-			return fmt.Errorf("invalid character %q (ASCII %d) - must be printable ASCII (32-126)", ch, ch)
+		if unicode.IsControl(ch) {
+			return fmt.Errorf("invalid character %q (U+%04X) - control characters cannot be rendered", ch, ch)
 		}
+		if _, ok := banner[ch]; !ok && !seen[ch] {
+			seen[ch] = true
+			missing = append(missing, fmt.Sprintf("%c (U+%04X)", ch, ch))
+		}
+	}
+
+	switch len(missing) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("character %s not found in banner", missing[0])
+	default:
+		return fmt.Errorf("characters %s not found in banner", strings.Join(missing, ", "))
 	}
-	return nil
 }
@@ -1,8 +1,10 @@
 package renderer_test
 
 import (
+	"errors"
 	"strings"
 	"testing"
+	"testing/iotest"
 
 	"ascii-art-fs/internal/renderer"
 )
@@ -252,6 +254,7 @@ func TestCorruptedBanner(t *testing.T) {
 	input := "A"
 	banner := map[rune][]string{
 		'A': {"A1", "A2", "A3", "A4", "A6", "A7", "A8"},
+		'B': {"B1", "B2", "B3", "B4", "B5", "B6", "B7", "B8"},
 	}
 	output, err := renderer.ASCII(input, banner)
 	if err == nil {
@@ -262,6 +265,40 @@ func TestCorruptedBanner(t *testing.T) {
 	}
 }
 
+func TestCustomGlyphHeight(t *testing.T) {
+	tests := []struct {
+		name   string
+		height int
+	}{
+		{"compact 5-row banner", 5},
+		{"single row banner", 1},
+		{"tall 12-row banner", 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			banner := map[rune][]string{
+				'A': make([]string, tt.height),
+				' ': make([]string, tt.height),
+			}
+			for i := 0; i < tt.height; i++ {
+				banner['A'][i] = "A"
+				banner[' '][i] = "."
+			}
+
+			output, err := renderer.ASCII("A A\nA", banner)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			expected := strings.Repeat("A.A\n", tt.height) + strings.Repeat("A\n", tt.height)
+			if output != expected {
+				t.Errorf("expected:\n%q\ngot:\n%q", expected, output)
+			}
+		})
+	}
+}
+
 func TestInvalidCharacters(t *testing.T) {
 	input := "A	B"
 	banner := map[rune][]string{
@@ -307,3 +344,119 @@ func TestCompleteASCIIRange(t *testing.T) {
 		}
 	}
 }
+
+func TestExtendedCharacters(t *testing.T) {
+	banner := map[rune][]string{
+		'A': {"A", "A"},
+		'Ω': {"W", "W"},
+		'é': {"e", "e"},
+	}
+	output, err := renderer.ASCII("AΩé", banner)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "AWe\nAWe\n"; output != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, output)
+	}
+}
+
+func TestUnsupportedCharactersListed(t *testing.T) {
+	banner := map[rune][]string{
+		'A': {"A1", "A2"},
+	}
+	_, err := renderer.ASCII("AΩβΩ", banner)
+	if err == nil {
+		t.Fatal("expected error for characters missing from the banner, got nil")
+	}
+	expectedMsg := "characters Ω (U+03A9), β (U+03B2) not found in banner"
+	if err.Error() != expectedMsg {
+		t.Errorf("expected %q, got %q", expectedMsg, err.Error())
+	}
+}
+
+// failingWriter accepts n writes, then fails.
+type failingWriter struct{ n int }
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.n == 0 {
+		return 0, errors.New("disk full")
+	}
+	w.n--
+	return len(p), nil
+}
+
+func TestRenderMatchesASCII(t *testing.T) {
+	banner := map[rune][]string{
+		'A': {"A1", "A2", "A3"},
+		'B': {"B1", "B2", "B3"},
+		' ': {"  ", "  ", "  "},
+	}
+	for _, input := range []string{"", "\n", "A", "A\n", "A\n\nB", "AB A\n\n\n", "\nB"} {
+		want, err := renderer.ASCII(input, banner)
+		if err != nil {
+			t.Fatalf("ASCII(%q) failed: %v", input, err)
+		}
+		var got strings.Builder
+		if err := renderer.Render(&got, strings.NewReader(input), banner); err != nil {
+			t.Fatalf("Render(%q) failed: %v", input, err)
+		}
+		if got.String() != want {
+			t.Errorf("Render(%q) = %q, want %q", input, got.String(), want)
+		}
+	}
+}
+
+func TestRenderCRLF(t *testing.T) {
+	banner := map[rune][]string{
+		'A': {"A1", "A2"},
+		'B': {"B1", "B2"},
+	}
+	for _, input := range []string{"A\r\n", "A\r\n\r\nB", "AB\r\nA\r\n"} {
+		for _, opts := range []renderer.Options{{}, {Vertical: true}} {
+			want, err := renderer.ASCIIWithOptions(strings.ReplaceAll(input, "\r\n", "\n"), banner, opts)
+			if err != nil {
+				t.Fatalf("ASCIIWithOptions(%q, %+v) failed: %v", input, opts, err)
+			}
+			var got strings.Builder
+			if err := renderer.RenderWithOptions(&got, strings.NewReader(input), banner, opts); err != nil {
+				t.Fatalf("RenderWithOptions(%q, %+v) failed: %v", input, opts, err)
+			}
+			if got.String() != want {
+				t.Errorf("RenderWithOptions(%q, %+v) = %q, want %q", input, opts, got.String(), want)
+			}
+		}
+	}
+
+	// A carriage return inside a line is still invalid.
+	if err := renderer.Render(&strings.Builder{}, strings.NewReader("A\rB\n"), banner); err == nil {
+		t.Error("expected an error for a carriage return inside a line")
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	banner := map[rune][]string{'A': {"A1", "A2"}}
+
+	// Blocks before an invalid line are already written.
+	var out strings.Builder
+	err := renderer.Render(&out, strings.NewReader("A\nAz\nA"), banner)
+	if err == nil || !strings.Contains(err.Error(), "z (U+007A) not found") {
+		t.Errorf("invalid line error = %v", err)
+	}
+	if out.String() != "A1\nA2\n" {
+		t.Errorf("output before the invalid line = %q", out.String())
+	}
+
+	err = renderer.Render(&failingWriter{n: 1}, strings.NewReader("A\nA\nA"), banner)
+	if err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Errorf("writer error = %v", err)
+	}
+
+	err = renderer.Render(&out, iotest.ErrReader(errors.New("broken pipe")), banner)
+	if err == nil || !strings.Contains(err.Error(), "failed to read input: broken pipe") {
+		t.Errorf("reader error = %v", err)
+	}
+
+	if err := renderer.Render(&out, strings.NewReader("A"), map[rune][]string{}); err == nil {
+		t.Error("expected an error for an empty banner")
+	}
+}
@@ -0,0 +1,128 @@
+package renderer
+
+import (
+	"fmt"
+	"strings"
+
+	"ascii-art-fs/internal/parser"
+)
+
+// Vertical renders input top to bottom, one glyph under the other, with every
+// input line as a column of its own and the columns side by side.
+//
+// Every glyph is centered within the width of the widest glyph of the input,
+// rounding the left padding down, so the columns look straight. Columns are
+// separated by the width of the banner's space glyph, or one column if the
+// banner has none, and a column shorter than the others is padded with blank
+// cells. An empty input line is an empty column. opts.LineSpacing blank rows
+// are added between two glyphs of a column, as wide as the other rows so that
+// every row has the same width; the width, alignment, layout and letter
+// spacing do not apply.
+//
+// Parameters:
+//   - input: The text to render; lines may end with "\n" or "\r\n", and a
+//     trailing newline does not add a column.
+//   - banner: A map associating each rune with its ASCII-art representation.
+//   - opts: The layout options; opts.Hardblank and opts.LineSpacing apply.
+//
+// Returns:
+//   - The rows of the art, without newlines; nil for empty input.
+//   - The byte offset in input of the character whose glyph drew each cell,
+//     or -1 for blank cells.
+//   - An error if input validation or banner validation fails.
+func Vertical(input string, banner parser.Banner, opts Options) ([]string, [][]int, error) {
+	input = strings.TrimSuffix(strings.TrimSuffix(input, "\n"), "\r")
+	if input == "" {
+		return nil, nil, nil
+	}
+	if len(banner) == 0 {
+		return nil, nil, fmt.Errorf("banner is empty")
+	}
+	if err := validateInput(strings.ReplaceAll(input, "\r\n", "\n"), banner); err != nil {
+		return nil, nil, err
+	}
+	bannerHeight, err := validateBannerHeight(banner)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Collect the glyph cells of every column, with the offset of their character.
+	type glyph struct {
+		offset int
+		cells  [][]rune
+	}
+	var columns [][]glyph
+	width, depth := 0, 0
+	column := []glyph{}
+	for offset, ch := range input {
+		if ch == '\r' && strings.HasPrefix(input[offset+1:], "\n") {
+			continue
+		}
+		if ch == '\n' {
+			columns = append(columns, column)
+			column = []glyph{}
+			continue
+		}
+		cells := glyphCells(ch, banner[ch], opts.Hardblank, 0)
+		width = max(width, len(cells[0]))
+		column = append(column, glyph{offset: offset, cells: cells})
+		depth = max(depth, len(column))
+	}
+	columns = append(columns, column)
+
+	gap := 1
+	if space, ok := banner[' ']; ok {
+		gap = len(glyphCells(' ', space, 0, 0)[0])
+	}
+	spacing := max(0, opts.LineSpacing)
+	rowWidth := len(columns)*width + (len(columns)-1)*gap
+
+	var rows []string
+	var owners [][]int
+	for i := 0; i < depth; i++ {
+		if i > 0 {
+			for n := 0; n < spacing; n++ {
+				row, rowOwners := appendBlank(nil, nil, rowWidth)
+				rows = append(rows, string(row))
+				owners = append(owners, rowOwners)
+			}
+		}
+		for y := 0; y < bannerHeight; y++ {
+			var row []rune
+			var rowOwners []int
+			for c, column := range columns {
+				if c > 0 {
+					row, rowOwners = appendBlank(row, rowOwners, gap)
+				}
+				if i >= len(column) {
+					row, rowOwners = appendBlank(row, rowOwners, width)
+					continue
+				}
+				g := column[i]
+				left := (width - len(g.cells[y])) / 2
+				row, rowOwners = appendBlank(row, rowOwners, left)
+				for _, cell := range g.cells[y] {
+					row = append(row, cell)
+					rowOwners = append(rowOwners, cellOwner(cell, g.offset))
+				}
+				row, rowOwners = appendBlank(row, rowOwners, width-left-len(g.cells[y]))
+			}
+			rows = append(rows, cellsString(row))
+			owners = append(owners, rowOwners)
+		}
+	}
+	if depth == 0 {
+		// Only empty lines: one empty row, as for an empty horizontal block.
+		return []string{""}, [][]int{nil}, nil
+	}
+	return rows, owners, nil
+}
+
+// appendBlank appends n blank cells without an owner to a row.
+func appendBlank(row []rune, owners []int, n int) ([]rune, []int) {
+	for ; n > 0; n-- {
+		row = append(row, ' ')
+		owners = append(owners, -1)
+	}
+	return row, owners
+}
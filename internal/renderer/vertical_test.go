@@ -0,0 +1,80 @@
+package renderer_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"ascii-art-fs/internal/parser"
+	"ascii-art-fs/internal/renderer"
+)
+
+func TestVertical(t *testing.T) {
+	banner := parser.Banner{
+		' ': {"  ", "  "},
+		'i': {"i", "i"},
+		'm': {"mmm", "m m"},
+		'h': {"h$h", "hhh"},
+	}
+	tests := []struct {
+		name  string
+		input string
+		opts  renderer.Options
+		want  string
+	}{
+		{"narrow glyphs are centered", "mi", renderer.Options{}, "mmm\nm m\n i \n i \n"},
+		{"gap rows", "mi", renderer.Options{LineSpacing: 1}, "mmm\nm m\n   \n i \n i \n"},
+		{"one column per line", "mi\ni", renderer.Options{}, "mmm   i \nm m   i \n i      \n i      \n"},
+		{"empty line column", "i\n\nm", renderer.Options{}, " i        mmm\n i        m m\n"},
+		{"trailing newline", "i\n", renderer.Options{}, "i\ni\n"},
+		{"hardblanks", "h", renderer.Options{Hardblank: '$'}, "h h\nhhh\n"},
+		{"empty input", "", renderer.Options{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Vertical = true
+			got, err := renderer.ASCIIWithOptions(tt.input, banner, tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got:\n%q\nwant:\n%q", got, tt.want)
+			}
+
+			var streamed strings.Builder
+			if err := renderer.RenderWithOptions(&streamed, strings.NewReader(tt.input), banner, tt.opts); err != nil {
+				t.Fatalf("RenderWithOptions: unexpected error: %v", err)
+			}
+			if streamed.String() != tt.want {
+				t.Errorf("RenderWithOptions got:\n%q\nwant:\n%q", streamed.String(), tt.want)
+			}
+		})
+	}
+
+	_, owners, err := renderer.Vertical("mi", banner, renderer.Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantOwners := [][]int{{0, 0, 0}, {0, -1, 0}, {-1, 1, -1}, {-1, 1, -1}}
+	if !reflect.DeepEqual(owners, wantOwners) {
+		t.Errorf("owners = %v, want %v", owners, wantOwners)
+	}
+
+	// Gap rows are as wide as the glyph rows, so every row has the same width.
+	rows, owners, err := renderer.Vertical("mi\ni", banner, renderer.Options{LineSpacing: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for y, row := range rows {
+		if len(row) != len(rows[0]) || len(owners[y]) != len(rows[0]) {
+			t.Errorf("row %d = %q with %d owners, want %d columns", y, row, len(owners[y]), len(rows[0]))
+		}
+	}
+
+	if _, _, err := renderer.Vertical("mx", banner, renderer.Options{}); err == nil {
+		t.Error("expected an error for a character missing from the banner")
+	}
+	if _, _, err := renderer.Vertical("m", parser.Banner{}, renderer.Options{}); err == nil {
+		t.Error("expected an error for an empty banner")
+	}
+}
@@ -0,0 +1,124 @@
+package renderer
+
+import (
+	"strings"
+
+	"ascii-art-fs/internal/parser"
+)
+
+// Segment is a part of an input line rendered as one block, given as the byte
+// offsets line[Start:End].
+type Segment struct {
+	Start, End int
+}
+
+// Wrap breaks an input line into segments whose rendered width is at most
+// width columns, measuring every character with the width of its glyph.
+//
+// Lines are broken at spaces; the spaces at a break are dropped, like in
+// wrapped prose. A word wider than width is broken between characters. A
+// single glyph wider than width gets a segment of its own, which then exceeds
+// width. Since segments are offsets into line, positions computed for the
+// whole line, such as color matches, can be sliced to match each segment.
+//
+// Parameters:
+//   - line: An input line without newlines.
+//   - banner: The banner whose glyph widths are measured.
+//   - width: The maximum width in columns; 0 or less disables wrapping.
+//
+// Returns:
+//   - The segments, in order; a single segment covering the line when it fits
+//     or wrapping is disabled.
+func Wrap(line string, banner parser.Banner, width int) []Segment {
+	return wrap(line, parser.CharWidths(line, banner), width)
+}
+
+// wrap breaks an input line into segments like Wrap, given the width of every
+// character indexed by byte offset.
+func wrap(line string, widths []int, width int) []Segment {
+	whole := []Segment{{Start: 0, End: len(line)}}
+	if width <= 0 {
+		return whole
+	}
+	if sum(widths) <= width {
+		return whole
+	}
+
+	var segments []Segment
+	start, end, used := -1, 0, 0 // the current segment and its width; start -1 when empty
+	flush := func() {
+		if start >= 0 {
+			segments = append(segments, Segment{Start: start, End: end})
+		}
+		start, used = -1, 0
+	}
+
+	for offset := 0; offset < len(line); {
+		wordEnd := offset + strings.IndexByte(line[offset:], ' ')
+		if wordEnd < offset {
+			wordEnd = len(line)
+		}
+		wordWidth := sum(widths[offset:wordEnd])
+		spaceWidth := 0
+		if start >= 0 && offset > end {
+			spaceWidth = sum(widths[end:offset])
+		}
+
+		switch {
+		case wordEnd == offset:
+			// Spaces are kept inside a segment and dropped at its start.
+		case start >= 0 && used+spaceWidth+wordWidth <= width:
+			end, used = wordEnd, used+spaceWidth+wordWidth
+		case wordWidth <= width:
+			flush()
+			start, end, used = offset, wordEnd, wordWidth
+		default:
+			// Break the word between characters, starting on a new segment.
+			flush()
+			for i := offset; i < wordEnd; i++ {
+				if i > offset && line[i]&0xC0 == 0x80 {
+					continue // continuation byte of a multi-byte character
+				}
+				w := widths[i]
+				if start >= 0 && used+w > width {
+					flush()
+				}
+				if start < 0 {
+					start = i
+				}
+				end, used = nextChar(line, i), used+w
+			}
+		}
+
+		offset = wordEnd
+		if offset < len(line) {
+			offset++ // skip the space; it is kept only if the segment continues
+		}
+	}
+	flush()
+
+	if len(segments) == 0 {
+		// A line of spaces: keep it as one segment rather than dropping it.
+		return whole
+	}
+	return segments
+}
+
+// nextChar returns the offset after the character starting at offset i.
+func nextChar(line string, i int) int {
+	for j := i + 1; j <= len(line); j++ {
+		if j == len(line) || line[j]&0xC0 != 0x80 {
+			return j
+		}
+	}
+	return len(line)
+}
+
+// sum adds up widths.
+func sum(widths []int) int {
+	total := 0
+	for _, w := range widths {
+		total += w
+	}
+	return total
+}
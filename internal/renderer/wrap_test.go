@@ -0,0 +1,69 @@
+package renderer_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"ascii-art-fs/internal/parser"
+	"ascii-art-fs/internal/renderer"
+)
+
+// narrowBanner has one-column glyphs for a-z, ' ' and 'é', and a three-column 'W'.
+func narrowBanner() parser.Banner {
+	b := parser.Banner{' ': {" "}, 'é': {"é"}, 'W': {"WWW"}}
+	for r := 'a'; r <= 'z'; r++ {
+		b[r] = []string{string(r)}
+	}
+	return b
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  string
+		width int
+		want  []string
+	}{
+		{"disabled", "ab cd ef", 0, []string{"ab cd ef"}},
+		{"fits", "ab cd", 5, []string{"ab cd"}},
+		{"word boundaries", "ab cd ef", 5, []string{"ab cd", "ef"}},
+		{"spaces dropped at breaks", "ab   cd", 3, []string{"ab", "cd"}},
+		{"spaces kept inside", "a  b cd", 4, []string{"a  b", "cd"}},
+		{"long word", "abcdefgh", 3, []string{"abc", "def", "gh"}},
+		{"long word after a short one", "ab abcdefg", 4, []string{"ab", "abcd", "efg"}},
+		{"wide glyphs", "aW aW", 4, []string{"aW", "aW"}},
+		{"glyph wider than width", "WW", 2, []string{"W", "W"}},
+		{"multi-byte characters", "éé éé", 3, []string{"éé", "éé"}},
+		{"only spaces", "      ", 2, []string{"      "}},
+		{"empty", "", 2, []string{""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, seg := range renderer.Wrap(tt.line, narrowBanner(), tt.width) {
+				got = append(got, tt.line[seg.Start:seg.End])
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Wrap(%q, %d) = %q, want %q", tt.line, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderWithWidth(t *testing.T) {
+	banner := narrowBanner()
+	input := "hello big world\n\nabc"
+	want := "hello\nbig\nworld\n\nabc\n"
+
+	got, err := renderer.ASCIIWithOptions(input, banner, renderer.Options{Width: 6})
+	if err != nil || got != want {
+		t.Errorf("ASCIIWithOptions = %q, %v, want %q", got, err, want)
+	}
+
+	var out strings.Builder
+	err = renderer.RenderWithOptions(&out, strings.NewReader(input), banner, renderer.Options{Width: 6})
+	if err != nil || out.String() != want {
+		t.Errorf("RenderWithOptions = %q, %v, want %q", out.String(), err, want)
+	}
+}
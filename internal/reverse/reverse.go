@@ -0,0 +1,268 @@
+// Package reverse recognizes rendered ASCII art and recovers the text it shows.
+//
+// Art written by the renderer is a sequence of blocks, one per input line:
+// a block is as many rows as the banner's glyphs are high, and an input line
+// that was empty is a single empty row. Within a block the glyphs sit side by
+// side, so Recognize reads a block column by column, trying every glyph of the
+// banner at the current column.
+//
+// Matching is ambiguous: a run of blank columns may be a space or the margins
+// of the glyphs around it, and a glyph may be the left part of a wider one.
+// Glyphs are tried widest first, and when the rest of the block cannot be
+// matched after a choice, the next glyph is tried instead.
+//
+// Responsibilities of this package:
+//   - Split rendered art into blocks and empty lines
+//   - Match column slices of a block against the glyphs of a banner, with backtracking
+//   - Report the line and column where no glyph matches
+package reverse
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"ascii-art-fs/internal/parser"
+)
+
+// ansiCode matches the ANSI escape sequences written by color mode, so that
+// colored art can be recognized as well.
+var ansiCode = regexp.MustCompile("\033\\[[0-9;]*[A-Za-z]")
+
+// Error reports where the art could not be recognized.
+type Error struct {
+	// Line is the 1-based line of the art where the unrecognized block starts.
+	Line int
+	// Column is the 1-based column of the block where no glyph matches.
+	Column int
+	// Reason describes the failure.
+	Reason string
+}
+
+// Error returns the position and reason of the failure.
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Reason)
+}
+
+// glyph is a banner glyph prepared for matching.
+type glyph struct {
+	r     rune
+	rows  [][]rune
+	width int
+}
+
+// recognizer matches art against the glyphs of one banner.
+type recognizer struct {
+	// glyphs are the banner's glyphs, widest first, in code point order
+	// among glyphs of the same width.
+	glyphs []glyph
+	height int
+}
+
+// Recognize recovers the text shown by rendered art.
+//
+// Rows may be shorter than the blocks they belong to, as when trailing
+// whitespace was stripped from a log: missing cells count as spaces. A
+// trailing space of the original text is lost in that case, since nothing
+// tells it apart from the stripped margin.
+//
+// Parameters:
+//   - art: The rendered art, with rows separated by '\n'; ANSI color codes
+//     and '\r' are ignored.
+//   - banner: The banner the art was rendered with.
+//
+// Returns:
+//   - The text, with '\n' between the input lines.
+//   - An error if the banner is empty or has glyphs of different heights, or
+//     an *Error if part of the art matches no glyph.
+func Recognize(art string, banner parser.Banner) (string, error) {
+	rec, err := newRecognizer(banner)
+	if err != nil {
+		return "", err
+	}
+
+	art = ansiCode.ReplaceAllString(strings.ReplaceAll(art, "\r", ""), "")
+	art = strings.TrimSuffix(art, "\n")
+	if art == "" {
+		return "", nil
+	}
+
+	rows := strings.Split(art, "\n")
+	lines, lineErr := rec.lines(rows, 0, make(map[int]*Error))
+	if lineErr != nil {
+		return "", lineErr
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// newRecognizer prepares the glyphs of a banner for matching. Glyphs without
+// width cannot be told apart in the art and are left out.
+func newRecognizer(banner parser.Banner) (*recognizer, error) {
+	if len(banner) == 0 {
+		return nil, errors.New("banner is empty")
+	}
+
+	rec := &recognizer{height: -1}
+	for r, rows := range banner {
+		if rec.height == -1 {
+			rec.height = len(rows)
+		} else if len(rows) != rec.height {
+			return nil, fmt.Errorf("glyph %q has %d rows, expected %d", r, len(rows), rec.height)
+		}
+		if len(rows) == 0 || rows[0] == "" {
+			continue
+		}
+
+		g := glyph{r: r, width: utf8.RuneCountInString(rows[0])}
+		for _, row := range rows {
+			cells := []rune(row)
+			for len(cells) < g.width {
+				cells = append(cells, ' ')
+			}
+			g.rows = append(g.rows, cells)
+		}
+		rec.glyphs = append(rec.glyphs, g)
+	}
+	if rec.height == 0 || len(rec.glyphs) == 0 {
+		return nil, errors.New("banner has no visible glyphs")
+	}
+
+	slices.SortFunc(rec.glyphs, func(a, b glyph) int {
+		if a.width != b.width {
+			return b.width - a.width
+		}
+		return int(a.r - b.r)
+	})
+	return rec, nil
+}
+
+// lines recognizes rows[start:] as blocks and empty lines.
+//
+// An empty row is first read as an empty input line, and otherwise as the
+// first row of a block whose margin was stripped.
+//
+// Parameters:
+//   - rows: All rows of the art.
+//   - start: The index of the first row to recognize.
+//   - failed: The errors of the starting rows already known to fail.
+//
+// Returns:
+//   - The recognized input lines.
+//   - The error that got furthest into the art if the rows cannot be recognized.
+func (rec *recognizer) lines(rows []string, start int, failed map[int]*Error) ([]string, *Error) {
+	if start == len(rows) {
+		return nil, nil
+	}
+	if err, ok := failed[start]; ok {
+		return nil, err
+	}
+
+	var furthest *Error
+	if rows[start] == "" {
+		rest, err := rec.lines(rows, start+1, failed)
+		if err == nil {
+			return append([]string{""}, rest...), nil
+		}
+		furthest = further(furthest, err)
+	}
+
+	if end := start + rec.height; end <= len(rows) {
+		text, err := rec.block(rows[start:end], start+1)
+		if err == nil {
+			rest, restErr := rec.lines(rows, end, failed)
+			if restErr == nil {
+				return append([]string{text}, rest...), nil
+			}
+			err = restErr
+		}
+		furthest = further(furthest, err)
+	} else {
+		furthest = further(furthest, &Error{
+			Line:   start + 1,
+			Column: 1,
+			Reason: fmt.Sprintf("block has %d rows, expected %d", len(rows)-start, rec.height),
+		})
+	}
+
+	failed[start] = furthest
+	return nil, furthest
+}
+
+// block recognizes the text of one block.
+//
+// Parameters:
+//   - rows: The rows of the block.
+//   - line: The 1-based line of the art where the block starts, for errors.
+//
+// Returns:
+//   - The text of the block.
+//   - An *Error at the furthest column reached if the block cannot be matched.
+func (rec *recognizer) block(rows []string, line int) (string, *Error) {
+	cells := make([][]rune, len(rows))
+	width := 0
+	for y, row := range rows {
+		cells[y] = []rune(row)
+		width = max(width, len(cells[y]))
+	}
+
+	failed := make([]bool, width)
+	furthest := 0
+	var match func(col int) ([]rune, bool)
+	match = func(col int) ([]rune, bool) {
+		if col >= width {
+			return nil, true
+		}
+		if failed[col] {
+			return nil, false
+		}
+		furthest = max(furthest, col)
+		for _, g := range rec.glyphs {
+			if !g.matches(cells, col) {
+				continue
+			}
+			if rest, ok := match(col + g.width); ok {
+				return append([]rune{g.r}, rest...), true
+			}
+		}
+		failed[col] = true
+		return nil, false
+	}
+
+	text, ok := match(0)
+	if !ok {
+		return "", &Error{Line: line, Column: furthest + 1, Reason: "no glyph matches"}
+	}
+	return string(text), nil
+}
+
+// matches reports whether the glyph appears in the block at column col.
+// Cells past the end of a row count as spaces.
+func (g glyph) matches(cells [][]rune, col int) bool {
+	for y, row := range g.rows {
+		for x, want := range row {
+			got := ' '
+			if col+x < len(cells[y]) {
+				got = cells[y][col+x]
+			}
+			if got != want {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// further returns the error positioned furthest into the art, preferring a
+// when both are at the same position.
+func further(a, b *Error) *Error {
+	if a == nil {
+		return b
+	}
+	if b.Line > a.Line || (b.Line == a.Line && b.Column > a.Column) {
+		return b
+	}
+	return a
+}
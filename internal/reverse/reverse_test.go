@@ -0,0 +1,127 @@
+package reverse_test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"ascii-art-fs/internal/parser"
+	"ascii-art-fs/internal/renderer"
+	"ascii-art-fs/internal/reverse"
+)
+
+// prefixBanner has two-row glyphs where 'a' looks like 'b' followed by the
+// left part of 'd', so reading "bd" needs backtracking, and 'e' has a blank
+// lower row, like glyphs whose margin was stripped.
+func prefixBanner() parser.Banner {
+	return parser.Banner{
+		' ': {" ", " "},
+		'a': {`/\`, "||"},
+		'b': {"/", "|"},
+		'c': {`\`, "|"},
+		'd': {`\-`, "|-"},
+		'e': {"-", " "},
+	}
+}
+
+func TestRecognize(t *testing.T) {
+	tests := []struct {
+		name string
+		art  string
+		want string
+	}{
+		{"single glyph", "/\\\n||\n", "a"},
+		{"widest glyph first", "/\\\n||", "a"},
+		{"backtracks from a prefix", "/\\-\n||-\n", "bd"},
+		{"spaces", "/  \\\n|  |\n", "b  c"},
+		{"empty lines", "/\n|\n\n/\\\n||\n", "b\n\na"},
+		{"stripped margins", "/-\n|\n", "be"},
+		{"ANSI codes ignored", "\033[38;2;255;0;0m/\033[0m\\\r\n||\r\n", "a"},
+		{"empty art", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := reverse.Recognize(tt.art, prefixBanner())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecognizeErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		art        string
+		wantLine   int
+		wantColumn int
+	}{
+		{"unknown glyph", "/\\?\n||?\n", 1, 3},
+		{"second block", "/\n|\n\n/x\n||\n", 4, 2},
+		{"incomplete block", "/\\\n||\n/\n", 3, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := reverse.Recognize(tt.art, prefixBanner())
+			var recErr *reverse.Error
+			if !errors.As(err, &recErr) {
+				t.Fatalf("expected *reverse.Error, got %v", err)
+			}
+			if recErr.Line != tt.wantLine || recErr.Column != tt.wantColumn {
+				t.Errorf("error at line %d, column %d, want line %d, column %d: %v",
+					recErr.Line, recErr.Column, tt.wantLine, tt.wantColumn, err)
+			}
+		})
+	}
+
+	if _, err := reverse.Recognize("x", parser.Banner{}); err == nil {
+		t.Error("expected an error for an empty banner")
+	}
+}
+
+func TestRecognizeRoundTrip(t *testing.T) {
+	testdata := os.DirFS("../../cmd/ascii-art/testdata")
+	texts := []string{
+		"Hello World",
+		"The quick brown fox jumps over the lazy dog",
+		"{[(<0123456789>)]}",
+		"a\n\nb c",
+		"  spaced  ",
+		`!"#$%&'*+,-./:;=?@\^_` + "`|~",
+	}
+	for _, name := range []string{"standard.txt", "shadow.txt", "thinkertoy.txt"} {
+		banner, err := parser.LoadBanner(testdata, name)
+		if err != nil {
+			t.Fatalf("failed to load %s: %v", name, err)
+		}
+		for _, text := range texts {
+			art, err := renderer.ASCII(text, banner)
+			if err != nil {
+				t.Fatalf("%s: failed to render %q: %v", name, text, err)
+			}
+			got, err := reverse.Recognize(art, banner)
+			if err != nil {
+				t.Errorf("%s: %q: unexpected error: %v", name, text, err)
+				continue
+			}
+			if got != text {
+				t.Errorf("%s: got %q, want %q", name, got, text)
+			}
+		}
+	}
+
+	// Logs often strip trailing whitespace; only trailing spaces of the text are lost.
+	banner, _ := parser.LoadBanner(testdata, "standard.txt")
+	art, _ := renderer.ASCII("Hi there", banner)
+	var stripped []string
+	for _, row := range strings.Split(art, "\n") {
+		stripped = append(stripped, strings.TrimRight(row, " "))
+	}
+	if got, err := reverse.Recognize(strings.Join(stripped, "\n"), banner); err != nil || got != "Hi there" {
+		t.Errorf("stripped art: got %q, %v", got, err)
+	}
+}
@@ -0,0 +1,300 @@
+// Package transform derives new banners from existing ones.
+//
+// A Transform takes a parser.Banner and returns a new banner with every glyph
+// changed the same way: made bolder, slanted, mirrored, flipped upside down or
+// scaled. The input banner is never modified, so transforms can be applied to
+// shared banners such as those cached by the banner registry. Transforms are
+// chained with Chain, or parsed from a comma-separated list with Parse.
+//
+// Responsibilities of this package:
+//   - Derive bold, italic, mirrored, flipped and scaled glyphs
+//   - Remap directional characters such as '/' and '(' when reflecting glyphs
+//   - Parse transform lists such as "italic,scale2"
+//
+// Glyph widths are measured in characters, so glyphs drawn with multi-byte
+// characters such as '█' are transformed correctly.
+package transform
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"ascii-art-fs/internal/parser"
+)
+
+const (
+	// MaxScale is the largest scale factor accepted by Parse.
+	MaxScale = 8
+
+	listSeparator = ","
+	scalePrefix   = "scale"
+)
+
+// Transform derives a new banner from a banner without modifying it.
+type Transform func(parser.Banner) parser.Banner
+
+var (
+	// mirrorPairs maps characters to their horizontal reflection.
+	mirrorPairs = pairs(`/\`, "()", "[]", "{}", "<>")
+	// flipPairs maps characters to their vertical reflection.
+	flipPairs = pairs(`/\`)
+)
+
+// pairs builds a symmetric character mapping from two-character strings.
+func pairs(list ...string) map[rune]rune {
+	m := make(map[rune]rune, 2*len(list))
+	for _, p := range list {
+		a, b := rune(p[0]), rune(p[1])
+		m[a], m[b] = b, a
+	}
+	return m
+}
+
+// Bold thickens every stroke by merging each glyph with a copy of itself
+// shifted one column to the right. Glyphs become one column wider.
+//
+// Parameters:
+//   - b: The banner to transform.
+//
+// Returns:
+//   - The bold banner.
+func Bold(b parser.Banner) parser.Banner {
+	return mapGlyphs(b, func(rows [][]rune) [][]rune {
+		out := make([][]rune, len(rows))
+		for y, row := range rows {
+			line := make([]rune, len(row)+1)
+			for x := range line {
+				switch {
+				case x < len(row) && row[x] != ' ':
+					line[x] = row[x]
+				case x > 0 && row[x-1] != ' ':
+					line[x] = row[x-1]
+				default:
+					line[x] = ' '
+				}
+			}
+			out[y] = line
+		}
+		return out
+	})
+}
+
+// Italic slants every glyph to the right by shifting its rows progressively:
+// the bottom rows stay in place and every two rows up move one column further
+// right. Glyphs of height h become (h-1)/2 columns wider.
+//
+// Parameters:
+//   - b: The banner to transform.
+//
+// Returns:
+//   - The italic banner.
+func Italic(b parser.Banner) parser.Banner {
+	return mapGlyphs(b, func(rows [][]rune) [][]rune {
+		extra := (len(rows) - 1) / 2
+		out := make([][]rune, len(rows))
+		for y, row := range rows {
+			shift := (len(rows) - 1 - y) / 2
+			line := append([]rune(strings.Repeat(" ", shift)), row...)
+			out[y] = append(line, []rune(strings.Repeat(" ", extra-shift))...)
+		}
+		return out
+	})
+}
+
+// Mirror reflects every glyph horizontally, swapping characters that have a
+// direction: '/' and '\', '(' and ')', '[' and ']', '{' and '}', '<' and '>'.
+//
+// Parameters:
+//   - b: The banner to transform.
+//
+// Returns:
+//   - The mirrored banner.
+func Mirror(b parser.Banner) parser.Banner {
+	return mapGlyphs(b, func(rows [][]rune) [][]rune {
+		out := make([][]rune, len(rows))
+		for y, row := range rows {
+			line := make([]rune, len(row))
+			for x, r := range row {
+				line[len(row)-1-x] = swap(r, mirrorPairs)
+			}
+			out[y] = line
+		}
+		return out
+	})
+}
+
+// Flip turns every glyph upside down by reversing its rows, swapping '/' and '\'.
+//
+// Parameters:
+//   - b: The banner to transform.
+//
+// Returns:
+//   - The flipped banner.
+func Flip(b parser.Banner) parser.Banner {
+	return mapGlyphs(b, func(rows [][]rune) [][]rune {
+		out := make([][]rune, len(rows))
+		for y, row := range rows {
+			line := make([]rune, len(row))
+			for x, r := range row {
+				line[x] = swap(r, flipPairs)
+			}
+			out[len(rows)-1-y] = line
+		}
+		return out
+	})
+}
+
+// Scale returns a transform that enlarges every glyph by whole factors,
+// repeating each character x times and each row y times.
+//
+// Parameters:
+//   - x: The horizontal factor, at least 1.
+//   - y: The vertical factor, at least 1.
+//
+// Returns:
+//   - The scaling transform.
+func Scale(x, y int) Transform {
+	return func(b parser.Banner) parser.Banner {
+		return mapGlyphs(b, func(rows [][]rune) [][]rune {
+			out := make([][]rune, 0, len(rows)*y)
+			for _, row := range rows {
+				line := make([]rune, 0, len(row)*x)
+				for _, r := range row {
+					for i := 0; i < x; i++ {
+						line = append(line, r)
+					}
+				}
+				for i := 0; i < y; i++ {
+					out = append(out, line)
+				}
+			}
+			return out
+		})
+	}
+}
+
+// Chain returns a transform that applies the given transforms in order.
+//
+// Parameters:
+//   - transforms: The transforms to apply, first to last.
+//
+// Returns:
+//   - The combined transform; with no transforms it returns the banner unchanged.
+func Chain(transforms ...Transform) Transform {
+	return func(b parser.Banner) parser.Banner {
+		for _, t := range transforms {
+			b = t(b)
+		}
+		return b
+	}
+}
+
+// Parse builds a transform from a comma-separated list of transform names,
+// applied from left to right.
+//
+// The names are "bold", "italic", "mirror", "flip", "scale<N>" for scaling by
+// N in both directions and "scale<X>x<Y>" for separate factors, for example
+// "italic,scale2" or "scale2x1". Factors range from 1 to MaxScale.
+//
+// Parameters:
+//   - spec: The transform list.
+//
+// Returns:
+//   - The combined transform.
+//   - An error naming the first unknown or invalid transform.
+func Parse(spec string) (Transform, error) {
+	var transforms []Transform
+	for _, name := range strings.Split(spec, listSeparator) {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "bold":
+			transforms = append(transforms, Bold)
+		case "italic":
+			transforms = append(transforms, Italic)
+		case "mirror":
+			transforms = append(transforms, Mirror)
+		case "flip":
+			transforms = append(transforms, Flip)
+		default:
+			factors, ok := strings.CutPrefix(name, scalePrefix)
+			if !ok {
+				return nil, fmt.Errorf("unknown transform %q: expected bold, italic, mirror, flip or scale<N>", name)
+			}
+			x, y, err := parseScale(factors)
+			if err != nil {
+				return nil, fmt.Errorf("invalid transform %q: %w", name, err)
+			}
+			transforms = append(transforms, Scale(x, y))
+		}
+	}
+	return Chain(transforms...), nil
+}
+
+// parseScale parses the factors of a scale transform, "N" or "XxY".
+func parseScale(factors string) (x, y int, err error) {
+	xs, ys, separate := strings.Cut(factors, "x")
+	if x, err = parseFactor(xs); err != nil {
+		return 0, 0, err
+	}
+	if !separate {
+		return x, x, nil
+	}
+	if y, err = parseFactor(ys); err != nil {
+		return 0, 0, err
+	}
+	return x, y, nil
+}
+
+// parseFactor parses one scale factor between 1 and MaxScale.
+func parseFactor(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 || n > MaxScale {
+		return 0, fmt.Errorf("scale factor must be a number from 1 to %d, got %q", MaxScale, s)
+	}
+	return n, nil
+}
+
+// mapGlyphs applies f to every glyph of b and returns the results as a new banner.
+//
+// Each glyph is handed to f as rows of characters padded with spaces to the
+// glyph's widest row, so reflections keep the rows aligned.
+//
+// Parameters:
+//   - b: The banner to transform.
+//   - f: The glyph transformation.
+//
+// Returns:
+//   - The new banner.
+func mapGlyphs(b parser.Banner, f func(rows [][]rune) [][]rune) parser.Banner {
+	out := make(parser.Banner, len(b))
+	for r, glyph := range b {
+		rows := make([][]rune, len(glyph))
+		width := 0
+		for y, row := range glyph {
+			rows[y] = []rune(row)
+			width = max(width, len(rows[y]))
+		}
+		for y := range rows {
+			for len(rows[y]) < width {
+				rows[y] = append(rows[y], ' ')
+			}
+		}
+
+		transformed := f(rows)
+		result := make([]string, len(transformed))
+		for y, row := range transformed {
+			result[y] = string(row)
+		}
+		out[r] = result
+	}
+	return out
+}
+
+// swap returns the counterpart of r in pairs, or r itself.
+func swap(r rune, pairs map[rune]rune) rune {
+	if other, ok := pairs[r]; ok {
+		return other
+	}
+	return r
+}
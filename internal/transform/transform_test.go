@@ -0,0 +1,111 @@
+package transform
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"ascii-art-fs/internal/parser"
+)
+
+// slash is a 3-row glyph with directional characters and a ragged last row.
+var slash = []string{
+	" /(",
+	"/ [",
+	"<",
+}
+
+func TestTransforms(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform Transform
+		want      []string
+	}{
+		{"bold", Bold, []string{" /((", "//[[", "<<  "}},
+		{"italic", Italic, []string{"  /(", "/ [ ", "<   "}},
+		{"mirror", Mirror, []string{")\\ ", "] \\", "  >"}},
+		{"flip", Flip, []string{"<  ", "\\ [", " \\("}},
+		{"scale", Scale(2, 2), []string{"  //((", "  //((", "//  [[", "//  [[", "<<    ", "<<    "}},
+		{"scale x only", Scale(3, 1), []string{"   ///(((", "///   [[[", "<<<      "}},
+		{"chain", Chain(Mirror, Mirror), []string{" /(", "/ [", "<  "}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := parser.Banner{'/': slash}
+			got := tt.transform(b)
+			if !reflect.DeepEqual(got['/'], tt.want) {
+				t.Errorf("glyph = %q, want %q", got['/'], tt.want)
+			}
+			if !reflect.DeepEqual(b['/'], slash) {
+				t.Error("the input banner was modified")
+			}
+		})
+	}
+}
+
+func TestBoldMultiByte(t *testing.T) {
+	got := Bold(parser.Banner{'I': {"█ ", " █"}})
+	if want := []string{"██ ", " ██"}; !reflect.DeepEqual(got['I'], want) {
+		t.Errorf("glyph = %q, want %q", got['I'], want)
+	}
+}
+
+func TestTransformsKeepBannersRenderable(t *testing.T) {
+	standard, err := parser.Load(os.DirFS("../../cmd/ascii-art/testdata"), "standard.txt")
+	if err != nil {
+		t.Fatalf("failed to load standard.txt: %v", err)
+	}
+	transform, err := Parse("bold,italic,mirror,flip,scale2x3")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	got := transform(standard)
+	if len(got) != len(standard) || got.Height() != 3*standard.Height() {
+		t.Fatalf("got %d glyphs of height %d", len(got), got.Height())
+	}
+	for r, rows := range got {
+		for _, row := range rows {
+			if len(row) != len(rows[0]) {
+				t.Fatalf("glyph %q has rows of different widths: %q", r, rows)
+			}
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		spec    string
+		wantErr string
+	}{
+		{"bold", ""},
+		{" Italic , scale2 ", ""},
+		{"scale3x1,mirror,flip", ""},
+		{"shadow", `unknown transform "shadow"`},
+		{"", `unknown transform ""`},
+		{"scale0", "scale factor must be a number from 1 to 8"},
+		{"scale2x", "scale factor must be a number from 1 to 8"},
+		{"scale99", "scale factor must be a number from 1 to 8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			transform, err := Parse(tt.spec)
+			if tt.wantErr == "" {
+				if err != nil || transform == nil {
+					t.Errorf("Parse(%q) = %v", tt.spec, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse(%q) error = %v, want %q", tt.spec, err, tt.wantErr)
+			}
+		})
+	}
+
+	transform, _ := Parse("italic,scale2")
+	want := Scale(2, 2)(Italic(parser.Banner{'/': slash}))
+	if got := transform(parser.Banner{'/': slash}); !reflect.DeepEqual(got, want) {
+		t.Errorf("italic,scale2 = %q, want %q", got['/'], want['/'])
+	}
+}